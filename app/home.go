@@ -56,10 +56,11 @@ func (h *Home) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 	h.welcomeText.SetValue(p86l.T("home.welcome"))
 	h.usernameText.SetValue(p86l.GetUsername())
 	h.installedText.SetValue(p86l.T("home.version"))
-	if dataFile.UsePreRelease {
-		h.versionText.SetValue(dataFile.InstalledPreRelease)
-	} else {
-		h.versionText.SetValue(dataFile.InstalledGame)
+	switch {
+	case dataFile.PinnedVersion != "":
+		h.versionText.SetValue(dataFile.PinnedVersion)
+	default:
+		h.versionText.SetValue(dataFile.ChannelState(model.Channel().Name).Installed)
 	}
 	h.playTimeText.SetValue(p86l.T("home.time"))
 	h.lastPlayedText.SetValue(p86l.T("home.last"))
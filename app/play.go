@@ -25,6 +25,11 @@ import (
 	"p86l"
 	"p86l/assets"
 	"p86l/configs"
+	"p86l/internal/asset"
+	"p86l/internal/channel"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget"
@@ -35,10 +40,13 @@ type Play struct {
 	guigui.DefaultWidget
 
 	actionButtons                                                   [3]basicwidget.Button
+	pinButton                                                       basicwidget.Button
+	taskButtons                                                     [4]basicwidget.Button
+	fileButtons                                                     [2]basicwidget.Button
 	form                                                            basicwidget.Form
 	gameVersionText, versionText, downloadsText, totalDownloadsText basicwidget.Text
-	prereleaseText                                                  basicwidget.Text
-	prereleaseToggle                                                basicwidget.Toggle
+	channelText, pinText, filesText, fileCheckText                  basicwidget.Text
+	channelSelect, pinSelect                                        basicwidget.Select[string]
 	changelogPanel                                                  basicwidget.Panel
 	changelogText                                                   basicwidget.Text
 	linkButtons                                                     [4]basicwidget.Button
@@ -48,6 +56,13 @@ func (p *Play) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 	for i := range p.actionButtons {
 		adder.AddChild(&p.actionButtons[i])
 	}
+	adder.AddChild(&p.pinButton)
+	for i := range p.taskButtons {
+		adder.AddChild(&p.taskButtons[i])
+	}
+	for i := range p.fileButtons {
+		adder.AddChild(&p.fileButtons[i])
+	}
 	adder.AddChild(&p.form)
 	adder.AddChild(&p.changelogPanel)
 	for i := range p.linkButtons {
@@ -58,30 +73,29 @@ func (p *Play) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 	data := model.Data()
 	dataFile := data.Get()
 	cacheFile := model.Cache().Get()
+	currentChannel := model.Channel()
+	latest := model.LatestRelease()
 
 	inProgress := model.InProgress()
 	if inProgress {
 		for i := range p.actionButtons {
 			context.SetEnabled(&p.actionButtons[i], !inProgress)
 		}
+		context.SetEnabled(&p.pinButton, !inProgress)
 	} else {
 		// Install & Play
 		var gameAvail, isNew bool
 		var currentVersion, latestVersion string
 
-		if dataFile.UsePreRelease {
-			gameAvail = model.CheckFilesCached(p86l.PathGamePreRelease)
-			currentVersion = dataFile.InstalledPreRelease
-
-			if cacheFile.Releases != nil {
-				latestVersion = cacheFile.Releases.PreRelease.TagName
-			}
+		if dataFile.PinnedVersion != "" {
+			// Pinned builds never update.
+			gameAvail = model.CheckFilesCached(p86l.PathGameVersion(dataFile.PinnedVersion, model.GameOS()))
 		} else {
-			gameAvail = model.CheckFilesCached(p86l.PathGameStable)
-			currentVersion = dataFile.InstalledGame
+			gameAvail = model.CheckFilesCached(filepath.Join(currentChannel.Path(), p86l.GameFile(model.GameOS())))
+			currentVersion = dataFile.ChannelState(currentChannel.Name).Installed
 
-			if cacheFile.Releases != nil {
-				latestVersion = cacheFile.Releases.Stable.TagName
+			if latest != nil {
+				latestVersion = latest.TagName
 			}
 		}
 
@@ -93,9 +107,11 @@ func (p *Play) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 			}
 		}
 
-		context.SetEnabled(&p.actionButtons[0], !gameAvail)
+		// Pinned builds are installed with the pin button, Install would fetch the channel instead.
+		context.SetEnabled(&p.actionButtons[0], !gameAvail && dataFile.PinnedVersion == "")
 		context.SetEnabled(&p.actionButtons[1], isNew)
 		context.SetEnabled(&p.actionButtons[2], gameAvail)
+		context.SetEnabled(&p.pinButton, !gameAvail && dataFile.PinnedVersion != "")
 	}
 
 	actionTexts := [3]string{p86l.T("play.install"), p86l.T("play.update"), p86l.T("play.play")}
@@ -108,12 +124,48 @@ func (p *Play) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 		p.actionButtons[i].SetOnDown(func(context *guigui.Context) { go model.Play(actionTypes[i]) })
 	}
 
+	p.pinButton.SetText(p86l.T("play.install_version"))
+	p.pinButton.SetOnDown(func(context *guigui.Context) {
+		if pinned := data.Get().PinnedVersion; pinned != "" {
+			go model.InstallVersion(pinned)
+		}
+	})
+
+	// Pause, Resume, Cancel & Rollback
+	taskRunning, paused := model.TaskRunning(), model.Paused()
+	context.SetEnabled(&p.taskButtons[0], taskRunning)
+	context.SetEnabled(&p.taskButtons[1], paused && !inProgress)
+	context.SetEnabled(&p.taskButtons[2], taskRunning || paused)
+	context.SetEnabled(&p.taskButtons[3], model.HasBackup() && !inProgress && !paused)
+
+	taskTexts := [4]string{p86l.T("play.pause"), p86l.T("play.resume"), p86l.T("play.cancel"), p86l.T("play.rollback")}
+	taskFns := [4]func(){model.PauseTask, model.ResumeTask, model.CancelTask, func() { go model.Rollback() }}
+	for i := range p.taskButtons {
+		p.taskButtons[i].SetText(taskTexts[i])
+		p.taskButtons[i].SetOnDown(func(context *guigui.Context) { taskFns[i]() })
+	}
+
+	// Verify files & Repair
+	fileCheck := model.FileCheck()
+	context.SetEnabled(&p.fileButtons[0], !inProgress && !paused)
+	context.SetEnabled(&p.fileButtons[1], fileCheck != nil && !fileCheck.OK() && !inProgress && !paused)
+
+	fileTexts := [2]string{p86l.T("play.verify"), p86l.T("play.repair")}
+	fileFns := [2]func(){model.VerifyFiles, model.Repair}
+	for i := range p.fileButtons {
+		p.fileButtons[i].SetText(fileTexts[i])
+		p.fileButtons[i].SetOnDown(func(context *guigui.Context) { go fileFns[i]() })
+	}
+
+	p.fileCheckText.SetMultiline(true)
+	p.fileCheckText.SetValue(fileCheckSummary(fileCheck))
+
 	p.changelogText.SetAutoWrap(true)
 	p.changelogText.SetMultiline(true)
-	if cacheFile.Releases != nil && dataFile.TranslateChangelog && dataFile.Lang != "en" {
+	if latest != nil && dataFile.TranslateChangelog && dataFile.Lang != "en" {
 		p.changelogText.SetValue(cacheFile.ChangelogTranslation)
 	} else {
-		p.changelogText.SetValue(p86l.ReleasesChangelogText(cacheFile, dataFile.UsePreRelease))
+		p.changelogText.SetValue(p86l.ReleasesChangelogText(latest))
 	}
 
 	p.changelogPanel.SetContent(&p.changelogText)
@@ -122,25 +174,64 @@ func (p *Play) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 
 	p.gameVersionText.SetValue(p86l.T("play.version"))
 	p.downloadsText.SetValue(p86l.T("play.total"))
-	p.prereleaseText.SetValue(p86l.T("play.prerelease"))
+	p.channelText.SetValue(p86l.T("play.channel"))
+	p.pinText.SetValue(p86l.T("play.pin"))
+	p.filesText.SetValue(p86l.T("play.files"))
 
-	p.versionText.SetValue(p86l.GameVersionText(cacheFile, dataFile.UsePreRelease))
-	p.totalDownloadsText.SetValue(p86l.ReleasesDownloadCountText(cacheFile, dataFile.UsePreRelease))
+	if dataFile.PinnedVersion != "" {
+		p.versionText.SetValue(dataFile.PinnedVersion)
+	} else {
+		p.versionText.SetValue(p86l.GameVersionText(latest))
+	}
+	p.totalDownloadsText.SetValue(model.ReleasesDownloadCountText(latest))
 
-	p.prereleaseToggle.SetOnValueChanged(func(context *guigui.Context, value bool) {
-		data.Update(func(df *p86l.DataFile) {
-			df.UsePreRelease = value
+	var channelItems []basicwidget.SelectItem[string]
+	for _, c := range model.Channels() {
+		channelItems = append(channelItems, basicwidget.SelectItem[string]{
+			Text:  channelName(c),
+			Value: c.Name,
 		})
+	}
+	p.channelSelect.SetItems(channelItems)
+	p.channelSelect.SetOnItemSelected(func(context *guigui.Context, index int) {
+		item, ok := p.channelSelect.ItemByIndex(index)
+		if !ok || item.Value == model.Channel().Name {
+			return
+		}
+		model.SetChannel(item.Value)
+	})
+	if !p.channelSelect.IsPopupOpen() {
+		p.channelSelect.SelectItemByValue(currentChannel.Name)
+	}
+	context.SetEnabled(&p.channelSelect, !inProgress)
 
-		if cacheFile.Releases != nil {
-			model.Translate(p86l.ReleasesChangelogText(cacheFile, value), dataFile.Lang)
+	pinItems := []basicwidget.SelectItem[string]{
+		{
+			Text:  p86l.T("play.latest"),
+			Value: "",
+		},
+	}
+	for _, release := range cacheFile.AllReleases {
+		if _, err := model.GameAsset(&release); err != nil {
+			continue
 		}
+		pinItems = append(pinItems, basicwidget.SelectItem[string]{
+			Text:  release.TagName,
+			Value: release.TagName,
+		})
+	}
+	p.pinSelect.SetItems(pinItems)
+	p.pinSelect.SetOnItemSelected(func(context *guigui.Context, index int) {
+		item, ok := p.pinSelect.ItemByIndex(index)
+		if !ok || item.Value == data.Get().PinnedVersion {
+			return
+		}
+		model.PinVersion(item.Value)
 	})
-	if dataFile.UsePreRelease {
-		p.prereleaseToggle.SetValue(true)
-	} else {
-		p.prereleaseToggle.SetValue(false)
+	if !p.pinSelect.IsPopupOpen() {
+		p.pinSelect.SelectItemByValue(dataFile.PinnedVersion)
 	}
+	context.SetEnabled(&p.pinSelect, !inProgress)
 
 	p.form.SetItems([]basicwidget.FormItem{
 		{
@@ -152,8 +243,16 @@ func (p *Play) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 			SecondaryWidget: &p.totalDownloadsText,
 		},
 		{
-			PrimaryWidget:   &p.prereleaseText,
-			SecondaryWidget: &p.prereleaseToggle,
+			PrimaryWidget:   &p.channelText,
+			SecondaryWidget: &p.channelSelect,
+		},
+		{
+			PrimaryWidget:   &p.pinText,
+			SecondaryWidget: &p.pinSelect,
+		},
+		{
+			PrimaryWidget:   &p.filesText,
+			SecondaryWidget: &p.fileCheckText,
 		},
 	})
 
@@ -223,6 +322,64 @@ func (p *Play) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 							Widget: &p.actionButtons[2],
 							Size:   guigui.FixedSize(u * 4),
 						},
+						{
+							Widget: &p.pinButton,
+							Size:   guigui.FixedSize(u * 6),
+						},
+						{
+							Size: guigui.FlexibleSize(1),
+						},
+					},
+					Gap: u / 2,
+				},
+			},
+			{
+				Size: guigui.FixedSize(u),
+				Layout: guigui.LinearLayout{
+					Direction: guigui.LayoutDirectionHorizontal,
+					Items: []guigui.LinearLayoutItem{
+						{
+							Size: guigui.FlexibleSize(1),
+						},
+						{
+							Widget: &p.taskButtons[0],
+							Size:   guigui.FixedSize(u * 4),
+						},
+						{
+							Widget: &p.taskButtons[1],
+							Size:   guigui.FixedSize(u * 4),
+						},
+						{
+							Widget: &p.taskButtons[2],
+							Size:   guigui.FixedSize(u * 4),
+						},
+						{
+							Widget: &p.taskButtons[3],
+							Size:   guigui.FixedSize(u * 4),
+						},
+						{
+							Size: guigui.FlexibleSize(1),
+						},
+					},
+					Gap: u / 2,
+				},
+			},
+			{
+				Size: guigui.FixedSize(u),
+				Layout: guigui.LinearLayout{
+					Direction: guigui.LayoutDirectionHorizontal,
+					Items: []guigui.LinearLayoutItem{
+						{
+							Size: guigui.FlexibleSize(1),
+						},
+						{
+							Widget: &p.fileButtons[0],
+							Size:   guigui.FixedSize(u * 6),
+						},
+						{
+							Widget: &p.fileButtons[1],
+							Size:   guigui.FixedSize(u * 4),
+						},
 						{
 							Size: guigui.FlexibleSize(1),
 						},
@@ -254,3 +411,33 @@ func (p *Play) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 		},
 	}).LayoutWidgets(context, widgetBounds.Bounds(), layouter)
 }
+
+// channelName translates the names of the default channels.
+func channelName(c channel.Channel) string {
+	switch c.Name {
+	case asset.ChannelStable:
+		return p86l.T("play.channel_stable")
+	case asset.ChannelPreRelease:
+		return p86l.T("play.channel_prerelease")
+	default:
+		return c.Name
+	}
+}
+
+// fileCheckSummary sums up a FileCheck with the first few files at fault.
+func fileCheckSummary(check *p86l.FileCheck) string {
+	if check == nil {
+		return p86l.T("play.files_unchecked")
+	}
+
+	const maxFiles = 5
+	lines := []string{check.String()}
+	for _, path := range slices.Concat(check.Missing, check.Modified) {
+		if len(lines) > maxFiles {
+			lines = append(lines, "...")
+			break
+		}
+		lines = append(lines, path)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,208 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package app
+
+import (
+	"fmt"
+	"image/color"
+	"p86l"
+
+	"github.com/dustin/go-humanize"
+	"github.com/guigui-gui/guigui"
+	"github.com/guigui-gui/guigui/basicwidget"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// progressPanel shows the last progress event of an install, it lives in Root so it stays
+// visible on every page, and keeps the summary once the install is over.
+type progressPanel struct {
+	guigui.DefaultWidget
+
+	bar                                         progressBar
+	form                                        basicwidget.Form
+	phaseText, sizeText, speedText, etaText     basicwidget.Text
+	phaseValue, sizeValue, speedValue, etaValue basicwidget.Text
+	dismissButton                               basicwidget.Button
+}
+
+func (p *progressPanel) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
+	adder.AddChild(&p.bar)
+	adder.AddChild(&p.form)
+	adder.AddChild(&p.dismissButton)
+
+	model := context.Model(p, modelKeyModel).(*p86l.Model)
+	event := model.Progress()
+
+	p.bar.SetFraction(event.Fraction())
+
+	p.phaseText.SetValue(p86l.T("progress.phase"))
+	p.sizeText.SetValue(p86l.T("progress.size"))
+	p.speedText.SetValue(p86l.T("progress.speed"))
+	p.etaText.SetValue(p86l.T("progress.eta"))
+
+	phase := progressPhaseText(event.Phase)
+	if event.Tag != "" {
+		phase = fmt.Sprintf("%s - %s", phase, event.Tag)
+	}
+	if event.Phase.IsFinal() {
+		// The summary, e.g. the error of a failed install.
+		phase = fmt.Sprintf("%s\n%s", phase, event.String())
+	}
+	p.phaseValue.SetMultiline(true)
+	p.phaseValue.SetAutoWrap(true)
+	p.phaseValue.SetValue(phase)
+
+	percent := int(event.Fraction() * 100)
+	switch {
+	case event.Phase == p86l.PhaseExtract:
+		p.sizeValue.SetValue(fmt.Sprintf("%d / %d %s (%d%%)", event.FilesDone, event.FilesTotal, p86l.T("progress.files"), percent))
+	case event.BytesTotal > 0:
+		p.sizeValue.SetValue(fmt.Sprintf("%s / %s (%d%%)", humanize.Bytes(uint64(event.BytesDone)), humanize.Bytes(uint64(event.BytesTotal)), percent))
+	default:
+		p.sizeValue.SetValue("-")
+	}
+
+	if event.Phase == p86l.PhaseDownload && event.BytesDone < event.BytesTotal {
+		p.speedValue.SetValue(event.Rate())
+		p.etaValue.SetValue(event.Remaining())
+	} else {
+		p.speedValue.SetValue("-")
+		p.etaValue.SetValue("-")
+	}
+
+	p.form.SetItems([]basicwidget.FormItem{
+		{
+			PrimaryWidget:   &p.phaseText,
+			SecondaryWidget: &p.phaseValue,
+		},
+		{
+			PrimaryWidget:   &p.sizeText,
+			SecondaryWidget: &p.sizeValue,
+		},
+		{
+			PrimaryWidget:   &p.speedText,
+			SecondaryWidget: &p.speedValue,
+		},
+		{
+			PrimaryWidget:   &p.etaText,
+			SecondaryWidget: &p.etaValue,
+		},
+	})
+
+	p.dismissButton.SetText(p86l.T("progress.dismiss"))
+	p.dismissButton.SetOnDown(func(context *guigui.Context) { model.ClearProgress() })
+	context.SetEnabled(&p.dismissButton, event.Phase.IsFinal() && !model.Paused())
+
+	return nil
+}
+
+func (p *progressPanel) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds, layouter *guigui.ChildLayouter) {
+	u := basicwidget.UnitSize(context)
+	(guigui.LinearLayout{
+		Direction: guigui.LayoutDirectionVertical,
+		Items: []guigui.LinearLayoutItem{
+			{
+				Widget: &p.bar,
+				Size:   guigui.FixedSize(u / 3),
+			},
+			{
+				Widget: &p.form,
+				Size:   guigui.FlexibleSize(1),
+			},
+			{
+				Size: guigui.FixedSize(u),
+				Layout: guigui.LinearLayout{
+					Direction: guigui.LayoutDirectionHorizontal,
+					Items: []guigui.LinearLayoutItem{
+						{
+							Size: guigui.FlexibleSize(1),
+						},
+						{
+							Widget: &p.dismissButton,
+							Size:   guigui.FixedSize(u * 4),
+						},
+					},
+				},
+			},
+		},
+		Gap: u / 4,
+		Padding: guigui.Padding{
+			Start:  u / 2,
+			Top:    u / 4,
+			End:    u / 2,
+			Bottom: u / 4,
+		},
+	}).LayoutWidgets(context, widgetBounds.Bounds(), layouter)
+}
+
+func progressPhaseText(phase p86l.ProgressPhase) string {
+	switch phase {
+	case p86l.PhaseDownload:
+		return p86l.T("progress.download")
+	case p86l.PhaseVerify:
+		return p86l.T("progress.verify")
+	case p86l.PhaseExtract:
+		return p86l.T("progress.extract")
+	case p86l.PhaseCleanup:
+		return p86l.T("progress.cleanup")
+	case p86l.PhaseDone:
+		return p86l.T("progress.done")
+	case p86l.PhasePaused:
+		return p86l.T("progress.paused")
+	case p86l.PhaseCanceled:
+		return p86l.T("progress.canceled")
+	case p86l.PhaseFailed:
+		return p86l.T("progress.failed")
+	}
+	return ""
+}
+
+type progressBar struct {
+	guigui.DefaultWidget
+
+	fraction float64
+}
+
+func (p *progressBar) SetFraction(fraction float64) {
+	fraction = min(max(fraction, 0), 1)
+	if p.fraction == fraction {
+		return
+	}
+	p.fraction = fraction
+	guigui.RequestRedraw(p)
+}
+
+func (p *progressBar) Draw(context *guigui.Context, widgetBounds *guigui.WidgetBounds, dst *ebiten.Image) {
+	track := color.RGBA{0xd0, 0xd0, 0xd0, 0xff}
+	fill := color.RGBA{0x3a, 0x7b, 0xd5, 0xff}
+	if context.ColorMode() == guigui.ColorModeDark {
+		track = color.RGBA{0x40, 0x40, 0x40, 0xff}
+		fill = color.RGBA{0x5a, 0x9b, 0xf5, 0xff}
+	}
+
+	b := widgetBounds.Bounds()
+	x, y := float32(b.Min.X), float32(b.Min.Y)
+	w, h := float32(b.Dx()), float32(b.Dy())
+	vector.FillRect(dst, x, y, w, h, track, false)
+	vector.FillRect(dst, x, y, w*float32(p.fraction), h, fill, false)
+}
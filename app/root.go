@@ -57,6 +57,7 @@ type Root struct {
 	play     Play
 	settings Settings
 	about    About
+	progress progressPanel
 
 	toastBackground basicwidget.Background
 	toastForm       basicwidget.Form
@@ -97,12 +98,18 @@ func NewRoot(VERSION string) (*Root, *p86l.Model, *file.Filesystem, *zerolog.Log
 
 	model := p86l.NewModel(logger, logCapture, fs, player)
 
+	if log.HasDebugToken(VERSION, "nosig") {
+		logger.Warn().Str(log.Lifecycle, "signature verification disabled by P86L_DEBUG=nosig").Msg(log.AppManager.String())
+		model.SetAllowUnsigned(true)
+	}
+
 	if !noFS {
 		dataSubModel := p86l.NewDataSubModel(model)
 		model.AddSubModel(dataSubModel)
 	}
 	if !noAPI {
 		cacheSubModel := p86l.NewCacheSubModel(model)
+		model.SetClient(cacheSubModel.Client())
 		model.AddSubModel(cacheSubModel)
 	}
 
@@ -170,6 +177,25 @@ func (r *Root) contentWidget() guigui.Widget {
 	return nil
 }
 
+func (r *Root) progressWidget() guigui.Widget {
+	if !r.progressVisible() {
+		return nil
+	}
+	return &r.progress
+}
+
+func (r *Root) progressHeight(u int) int {
+	if !r.progressVisible() {
+		return 0
+	}
+	return u * 6
+}
+
+// progressVisible reports whether an install is running or its summary was not dismissed.
+func (r *Root) progressVisible() bool {
+	return r.model.Progress().Phase != p86l.PhaseIdle
+}
+
 func (r *Root) Model(key any) any {
 	switch key {
 	case modelKeyModel:
@@ -193,6 +219,9 @@ func (r *Root) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 	if content := r.contentWidget(); content != nil {
 		adder.AddChild(content)
 	}
+	if r.progressVisible() {
+		adder.AddChild(&r.progress)
+	}
 
 	captureText := r.model.LogCaptureText()
 
@@ -210,10 +239,12 @@ func (r *Root) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 		r.model.SetUIRefreshFn(func() {
 			guigui.RequestRedraw(r)
 		})
+		r.model.SubscribeProgress(func(p86l.ProgressEvent) {
+			guigui.RequestRedraw(r)
+		})
 		r.model.SetSyncDataFn(func(m *p86l.Model, value bool) error {
 			data := m.Data()
 			dataFile := m.Data().Get()
-			cacheFile := m.Cache().Get()
 
 			tag, err := data.Lang()
 			if err != nil {
@@ -236,8 +267,8 @@ func (r *Root) Build(context *guigui.Context, adder *guigui.ChildAdder) error {
 				}
 			}
 
-			if cacheFile.Releases != nil {
-				m.Translate(p86l.ReleasesChangelogText(cacheFile, dataFile.UsePreRelease), tag.String())
+			if latest := m.LatestRelease(); latest != nil {
+				m.Translate(p86l.ReleasesChangelogText(latest), tag.String())
 			}
 
 			context.SetAppScale(dataFile.AppScale)
@@ -351,6 +382,10 @@ func (r *Root) Layout(context *guigui.Context, widgetBounds *guigui.WidgetBounds
 										Widget: r.contentWidget(),
 										Size:   guigui.FlexibleSize(1),
 									},
+									{
+										Widget: r.progressWidget(),
+										Size:   guigui.FixedSize(r.progressHeight(u)),
+									},
 								},
 							},
 						},
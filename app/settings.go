@@ -27,6 +27,7 @@ import (
 	"p86l/configs"
 	"path/filepath"
 
+	"github.com/dustin/go-humanize"
 	"github.com/guigui-gui/guigui"
 	"github.com/guigui-gui/guigui/basicwidget"
 	"golang.org/x/text/language"
@@ -40,6 +41,8 @@ type Settings struct {
 	languageText, translateChangelogText, darkModeText, scaleText, rememberWindowText, disableBgmText basicwidget.Text
 	translateChangelogToggle, darkModeToggle, rememberWindowToggle, disableBgmToggle                  basicwidget.Toggle
 	languageSelect                                                                                    basicwidget.Select[language.Tag]
+	downloadLimitText                                                                                 basicwidget.Text
+	downloadLimitSelect                                                                               basicwidget.Select[int64]
 	scaleSegmentedControl                                                                             basicwidget.SegmentedControl[float64]
 	companyText, launcherText, logsText                                                               basicwidget.Text
 	companyButton, launcherButton, logsButton                                                         basicwidget.Button
@@ -53,7 +56,6 @@ func (s *Settings) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 	model := context.Model(s, modelKeyModel).(*p86l.Model)
 	data := model.Data()
 	dataFile := data.Get()
-	cacheFile := model.Cache().Get()
 
 	s.languageText.SetValue(p86l.T("settings.language"))
 	s.translateChangelogText.SetValue(p86l.T("settings.translate"))
@@ -61,6 +63,7 @@ func (s *Settings) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 	s.scaleText.SetValue(p86l.T("settings.scale"))
 	s.rememberWindowText.SetValue(p86l.T("settings.remember"))
 	s.disableBgmText.SetValue(p86l.T("settings.backgroundm"))
+	s.downloadLimitText.SetValue(p86l.T("settings.download_limit"))
 
 	s.languageSelect.SetItems([]basicwidget.SelectItem[language.Tag]{
 		{
@@ -85,8 +88,8 @@ func (s *Settings) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 			df.Lang = item.Value.String()
 		})
 
-		if cacheFile.Releases != nil {
-			model.Translate(p86l.ReleasesChangelogText(cacheFile, dataFile.UsePreRelease), item.Value.String())
+		if latest := model.LatestRelease(); latest != nil {
+			model.Translate(p86l.ReleasesChangelogText(latest), item.Value.String())
 		}
 	})
 	if !s.languageSelect.IsPopupOpen() {
@@ -103,8 +106,8 @@ func (s *Settings) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 			df.TranslateChangelog = value
 		})
 
-		if cacheFile.Releases != nil {
-			model.Translate(p86l.ReleasesChangelogText(cacheFile, dataFile.UsePreRelease), dataFile.Lang)
+		if latest := model.LatestRelease(); latest != nil {
+			model.Translate(p86l.ReleasesChangelogText(latest), dataFile.Lang)
 		}
 	})
 	if dataFile.TranslateChangelog {
@@ -191,6 +194,30 @@ func (s *Settings) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 		s.disableBgmToggle.SetValue(false)
 	}
 
+	limitItems := []basicwidget.SelectItem[int64]{
+		{
+			Text:  p86l.T("settings.unlimited"),
+			Value: 0,
+		},
+	}
+	for _, limit := range []int64{500_000, 1_000_000, 2_000_000, 5_000_000, 10_000_000, 25_000_000} {
+		limitItems = append(limitItems, basicwidget.SelectItem[int64]{
+			Text:  humanize.Bytes(uint64(limit)) + "/s",
+			Value: limit,
+		})
+	}
+	s.downloadLimitSelect.SetItems(limitItems)
+	s.downloadLimitSelect.SetOnItemSelected(func(context *guigui.Context, index int) {
+		item, ok := s.downloadLimitSelect.ItemByIndex(index)
+		if !ok || item.Value == data.Get().DownloadLimit {
+			return
+		}
+		model.SetDownloadLimit(item.Value)
+	})
+	if !s.downloadLimitSelect.IsPopupOpen() {
+		s.downloadLimitSelect.SelectItemByValue(dataFile.DownloadLimit)
+	}
+
 	launcherPath := configs.AppName
 	logsPath := filepath.Join(launcherPath, configs.FolderLogs)
 
@@ -244,6 +271,10 @@ func (s *Settings) Build(context *guigui.Context, adder *guigui.ChildAdder) erro
 			PrimaryWidget:   &s.disableBgmText,
 			SecondaryWidget: &s.disableBgmToggle,
 		},
+		{
+			PrimaryWidget:   &s.downloadLimitText,
+			SecondaryWidget: &s.downloadLimitSelect,
+		},
 		{
 			PrimaryWidget:   &s.companyText,
 			SecondaryWidget: &s.companyButton,
@@ -37,6 +37,13 @@ const (
 
 	FolderLogs = "logs"
 
+	EnvGithubToken = "P86L_GITHUB_TOKEN"
+	EnvGiteaToken  = "P86L_GITEA_TOKEN"
+
+	// Minisign public key (base64 line of the .pub file) trusted for game releases.
+	// Signatures are only enforced once it is set, dev builds can then skip the check with P86L_DEBUG=nosig.
+	SigningPublicKey = ""
+
 	FileData  = "data.json"
 	FileCache = "cache.json"
 
@@ -44,10 +51,19 @@ const (
 	FolderTemp       = "temp"
 	FolderPreRelease = "prerelease"
 	FolderStable     = "stable"
+	FolderVersions   = "versions"
+	FolderQuarantine = "quarantine"
+	FolderPrefixes   = "prefixes"
+	// Appended to a build folder while extracting and for the build replaced by the last update.
+	SuffixStaging = ".staging"
+	SuffixBackup  = ".backup"
 
-	FileStableZip     = "stable-build.zip"
-	FilePrereleaseZip = "prerelease-build.zip"
-	FileGame          = "Project-86.exe"
+	FileVersionZip = "%s-build.zip"
+	FileRepairZip  = "%s-%s-repair.zip"
+	FileGame       = "Project-86.exe"
+	FileGameLinux  = "Project-86.x86_64"
+	// Written into each build at install time, lists its files for verify and repair.
+	FileBuildManifest = ".p86l-manifest.json"
 
 	Website = "https://project-86-community.github.io/Project-86-Website/"
 	Github  = "https://github.com/Taliayaya/Project-86"
@@ -0,0 +1,135 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package archive
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/fs"
+	"p86l/internal/log"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DefaultMaxSize  = 64 << 30
+	DefaultMaxFiles = 100000
+	DefaultMaxRatio = 200
+
+	// Smaller entries skip the ratio check, a few kilobytes of padding compress far beyond any sane ratio.
+	ratioMinSize = 1 << 20
+)
+
+// Limits caps what an archive may extract to, zero fields use the defaults.
+type Limits struct {
+	// Total uncompressed bytes.
+	MaxSize uint64 `json:"max_size"`
+	// Entries, directories included.
+	MaxFiles int `json:"max_files"`
+	// Uncompressed over compressed size of a single entry.
+	MaxRatio float64 `json:"max_ratio"`
+}
+
+func (l Limits) withDefaults() Limits {
+	if l.MaxSize == 0 {
+		l.MaxSize = DefaultMaxSize
+	}
+	if l.MaxFiles <= 0 {
+		l.MaxFiles = DefaultMaxFiles
+	}
+	if l.MaxRatio <= 0 {
+		l.MaxRatio = DefaultMaxRatio
+	}
+	return l
+}
+
+// Error is an archive rejected as unsafe, as opposed to one that failed to read or extract.
+// It unwraps to one of the log.ErrArchive* errors.
+type Error struct {
+	// Entry at fault, empty when the archive as a whole is.
+	Name string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("%v: %q", e.Err, e.Name)
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks every entry before anything is extracted. archive/zip already fails
+// entries that decompress past their declared size, so checking the headers is enough.
+func Validate(files []*zip.File, limits Limits) error {
+	limits = limits.withDefaults()
+
+	if len(files) > limits.MaxFiles {
+		return &Error{Err: fmt.Errorf("%w: %d > %d", log.ErrArchiveFiles, len(files), limits.MaxFiles)}
+	}
+
+	var total uint64
+	for _, f := range files {
+		if err := checkEntry(f, limits); err != nil {
+			return err
+		}
+
+		total += f.UncompressedSize64
+		if total > limits.MaxSize {
+			return &Error{Err: fmt.Errorf("%w: more than %d bytes", log.ErrArchiveSize, limits.MaxSize)}
+		}
+	}
+
+	return nil
+}
+
+func checkEntry(f *zip.File, limits Limits) error {
+	if !IsLocal(f.Name) {
+		return &Error{Name: f.Name, Err: log.ErrArchivePath}
+	}
+
+	mode := f.Mode()
+	if mode&fs.ModeSymlink != 0 || !(mode.IsDir() || mode.IsRegular()) {
+		return &Error{Name: f.Name, Err: log.ErrArchiveEntryType}
+	}
+
+	if f.UncompressedSize64 >= ratioMinSize {
+		if f.CompressedSize64 == 0 || float64(f.UncompressedSize64)/float64(f.CompressedSize64) > limits.MaxRatio {
+			return &Error{Name: f.Name, Err: log.ErrArchiveRatio}
+		}
+	}
+
+	return nil
+}
+
+// IsLocal reports whether an entry name stays inside the folder it is extracted to,
+// whichever separator and platform the archive was made with.
+func IsLocal(name string) bool {
+	slashed := strings.ReplaceAll(name, "\\", "/")
+	if slashed == "" || strings.HasPrefix(slashed, "/") || strings.Contains(slashed, ":") {
+		return false
+	}
+	return filepath.IsLocal(filepath.FromSlash(strings.TrimSuffix(slashed, "/")))
+}
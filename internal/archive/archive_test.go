@@ -0,0 +1,108 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/fs"
+	"p86l/internal/archive"
+	"p86l/internal/log"
+	"testing"
+)
+
+type entry struct {
+	name string
+	mode fs.FileMode
+	data []byte
+}
+
+func buildZip(t *testing.T, entries ...entry) []*zip.File {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		if e.mode != 0 {
+			header.SetMode(e.mode)
+		}
+		fw, err := w.CreateHeader(header)
+		if err != nil {
+			t.Fatalf("failed to add %s: %v", e.name, err)
+		}
+		if _, err := fw.Write(e.data); err != nil {
+			t.Fatalf("failed to write %s: %v", e.name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read zip: %v", err)
+	}
+	return r.File
+}
+
+func TestValidate(t *testing.T) {
+	files := buildZip(t,
+		entry{name: "Project-86_Data/"},
+		entry{name: "Project-86_Data/level0", data: []byte("level")},
+		entry{name: "Project-86.exe", data: []byte("MZ")},
+	)
+	if err := archive.Validate(files, archive.Limits{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []entry
+		limits  archive.Limits
+		want    error
+	}{
+		{"parent", []entry{{name: "../evil.exe"}}, archive.Limits{}, log.ErrArchivePath},
+		{"nested parent", []entry{{name: "data/../../evil.exe"}}, archive.Limits{}, log.ErrArchivePath},
+		{"absolute", []entry{{name: "/etc/evil"}}, archive.Limits{}, log.ErrArchivePath},
+		{"backslash", []entry{{name: "..\\evil.exe"}}, archive.Limits{}, log.ErrArchivePath},
+		{"volume", []entry{{name: "C:\\Windows\\evil.exe"}}, archive.Limits{}, log.ErrArchivePath},
+		{"symlink", []entry{{name: "link", mode: fs.ModeSymlink | 0777, data: []byte("/etc/passwd")}}, archive.Limits{}, log.ErrArchiveEntryType},
+		{"files", []entry{{name: "a"}, {name: "b"}, {name: "c"}}, archive.Limits{MaxFiles: 2}, log.ErrArchiveFiles},
+		{"size", []entry{{name: "a", data: make([]byte, 64)}, {name: "b", data: make([]byte, 64)}}, archive.Limits{MaxSize: 100}, log.ErrArchiveSize},
+		{"ratio", []entry{{name: "zeros", data: make([]byte, 4<<20)}}, archive.Limits{}, log.ErrArchiveRatio},
+	}
+
+	for _, tt := range tests {
+		err := archive.Validate(buildZip(t, tt.entries...), tt.limits)
+		if !errors.Is(err, tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+		var archiveErr *archive.Error
+		if !errors.As(err, &archiveErr) {
+			t.Fatalf("%s: expected *archive.Error, got %T", tt.name, err)
+		}
+	}
+}
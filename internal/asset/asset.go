@@ -0,0 +1,195 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset
+
+import (
+	"fmt"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	ChannelStable     = "stable"
+	ChannelPreRelease = "prerelease"
+)
+
+// Rule selects the game asset of a release. Empty GOOS, GOARCH and Channel match any.
+// Patterns are globs (path.Match) on the asset name, or regexes when prefixed with "re:".
+type Rule struct {
+	GOOS    string   `json:"goos,omitempty"`
+	GOARCH  string   `json:"goarch,omitempty"`
+	Channel string   `json:"channel,omitempty"`
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Target is the platform and channel an asset is picked for.
+type Target struct {
+	GOOS    string
+	GOARCH  string
+	Channel string
+}
+
+// DefaultRules pick the Windows or Linux build, pre-releases may also ship it as a dev build.
+var DefaultRules = []Rule{
+	{
+		GOOS:    "windows",
+		Include: []string{"Project86-v*.zip"},
+		Exclude: []string{"*dev*", "*linux*", "*macOS*"},
+	},
+	{
+		GOOS:    "windows",
+		Channel: ChannelPreRelease,
+		Include: []string{"Project86-v*.zip"},
+		Exclude: []string{"*linux*", "*macOS*"},
+	},
+	{
+		GOOS:    "linux",
+		Include: []string{"Project86-v*linux*.zip"},
+		Exclude: []string{"*dev*"},
+	},
+	{
+		GOOS:    "linux",
+		Channel: ChannelPreRelease,
+		Include: []string{"Project86-v*linux*.zip"},
+	},
+}
+
+type pattern func(name string) bool
+
+type compiledRule struct {
+	Rule
+	include, exclude []pattern
+}
+
+func (r *compiledRule) appliesTo(target Target) bool {
+	return (r.GOOS == "" || r.GOOS == target.GOOS) &&
+		(r.GOARCH == "" || r.GOARCH == target.GOARCH) &&
+		(r.Channel == "" || r.Channel == target.Channel)
+}
+
+func (r *compiledRule) matches(name string) bool {
+	for _, p := range r.exclude {
+		if p(name) {
+			return false
+		}
+	}
+	for _, p := range r.include {
+		if p(name) {
+			return true
+		}
+	}
+	return false
+}
+
+type Matcher struct {
+	rules []compiledRule
+}
+
+// Pattern compiles a glob or "re:" regex the way rules do, for other release filters.
+func Pattern(expr string) (func(name string) bool, error) {
+	return compile(expr)
+}
+
+func compile(expr string) (pattern, error) {
+	if re, ok := strings.CutPrefix(expr, "re:"); ok {
+		compiled, err := regexp.Compile(re)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", log.ErrAssetPattern, expr, err)
+		}
+		return compiled.MatchString, nil
+	}
+
+	if _, err := path.Match(expr, ""); err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", log.ErrAssetPattern, expr, err)
+	}
+	return func(name string) bool {
+		ok, _ := path.Match(expr, name)
+		return ok
+	}, nil
+}
+
+// New compiles rules, they are tried in order and the first one matching any asset wins.
+func New(rules []Rule) (*Matcher, error) {
+	m := &Matcher{}
+
+	for i, rule := range rules {
+		if len(rule.Include) == 0 {
+			return nil, fmt.Errorf("%w: rule %d has no include pattern", log.ErrAssetPattern, i)
+		}
+
+		compiled := compiledRule{Rule: rule}
+		for _, expr := range rule.Include {
+			p, err := compile(expr)
+			if err != nil {
+				return nil, err
+			}
+			compiled.include = append(compiled.include, p)
+		}
+		for _, expr := range rule.Exclude {
+			p, err := compile(expr)
+			if err != nil {
+				return nil, err
+			}
+			compiled.exclude = append(compiled.exclude, p)
+		}
+		m.rules = append(m.rules, compiled)
+	}
+
+	return m, nil
+}
+
+// Match returns the asset for target, pointing into assets. It fails with log.ErrAssetNotFound
+// when no rule matches, or log.ErrAssetAmbiguous when the first matching rule matches several assets.
+func (m *Matcher) Match(assets []github.ReleaseAsset, target Target) (*github.ReleaseAsset, error) {
+	for i := range m.rules {
+		rule := &m.rules[i]
+		if !rule.appliesTo(target) {
+			continue
+		}
+
+		var found []int
+		for j := range assets {
+			if rule.matches(assets[j].Name) {
+				found = append(found, j)
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return &assets[found[0]], nil
+		default:
+			names := make([]string, len(found))
+			for k, j := range found {
+				names[k] = assets[j].Name
+			}
+			return nil, fmt.Errorf("%w: %s", log.ErrAssetAmbiguous, strings.Join(names, ", "))
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s %s", log.ErrAssetNotFound, target.GOOS, target.GOARCH, target.Channel)
+}
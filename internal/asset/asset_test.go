@@ -0,0 +1,120 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package asset_test
+
+import (
+	"errors"
+	"p86l/internal/asset"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"testing"
+)
+
+func assets(names ...string) []github.ReleaseAsset {
+	result := make([]github.ReleaseAsset, len(names))
+	for i, name := range names {
+		result[i] = github.ReleaseAsset{Name: name}
+	}
+	return result
+}
+
+func TestDefaultRules(t *testing.T) {
+	m, err := asset.New(asset.DefaultRules)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	windows := asset.Target{GOOS: "windows", GOARCH: "amd64", Channel: asset.ChannelStable}
+
+	list := assets("Project86-v0.1.0-linux.zip", "Project86-v0.1.0.zip", "Project86-v0.1.0-macOS.zip", "SHA256SUMS")
+	got, err := m.Match(list, windows)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+	if got != &list[1] {
+		t.Fatalf("unexpected asset: %s", got.Name)
+	}
+
+	list = assets("Project86-v0.2.0-dev.zip", "Project86-v0.2.0-linux.zip")
+	if _, err := m.Match(list, windows); !errors.Is(err, log.ErrAssetNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	windows.Channel = asset.ChannelPreRelease
+	if got, err := m.Match(list, windows); err != nil || got.Name != "Project86-v0.2.0-dev.zip" {
+		t.Fatalf("unexpected pre-release asset: %v, %v", got, err)
+	}
+
+	linux := asset.Target{GOOS: "linux", GOARCH: "amd64", Channel: asset.ChannelStable}
+	if got, err := m.Match(list, linux); err != nil || got.Name != "Project86-v0.2.0-linux.zip" {
+		t.Fatalf("unexpected linux asset: %v, %v", got, err)
+	}
+	if _, err := m.Match(list, asset.Target{GOOS: "darwin", GOARCH: "arm64"}); !errors.Is(err, log.ErrAssetNotFound) {
+		t.Fatalf("expected not found on darwin, got %v", err)
+	}
+}
+
+func TestPlatformRules(t *testing.T) {
+	m, err := asset.New([]asset.Rule{
+		{GOOS: "linux", GOARCH: "arm64", Include: []string{"*-linux-arm64.zip"}},
+		{GOOS: "linux", Include: []string{`re:(?i)-linux(-x86_64)?\.zip$`}},
+		{Include: []string{"*.zip"}, Exclude: []string{"*linux*"}},
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	list := assets("game.zip", "game-linux.zip", "game-linux-arm64.zip")
+
+	for _, tt := range []struct {
+		target asset.Target
+		want   string
+	}{
+		{asset.Target{GOOS: "linux", GOARCH: "arm64"}, "game-linux-arm64.zip"},
+		{asset.Target{GOOS: "linux", GOARCH: "amd64"}, "game-linux.zip"},
+		{asset.Target{GOOS: "windows", GOARCH: "amd64"}, "game.zip"},
+	} {
+		got, err := m.Match(list, tt.target)
+		if err != nil || got.Name != tt.want {
+			t.Fatalf("%v: expected %s, got %v, %v", tt.target, tt.want, got, err)
+		}
+	}
+}
+
+func TestAmbiguous(t *testing.T) {
+	m, err := asset.New([]asset.Rule{{Include: []string{"*.zip"}}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := m.Match(assets("a.zip", "b.zip"), asset.Target{}); !errors.Is(err, log.ErrAssetAmbiguous) {
+		t.Fatalf("expected ambiguous, got %v", err)
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	for _, rules := range [][]asset.Rule{
+		{{Include: []string{"re:("}}},
+		{{Include: []string{"[a-"}}},
+		{{Exclude: []string{"*"}}},
+	} {
+		if _, err := asset.New(rules); !errors.Is(err, log.ErrAssetPattern) {
+			t.Fatalf("expected pattern error for %v, got %v", rules, err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package bsdiff applies patches made by the bsdiff tool (BSDIFF40 format).
+package bsdiff
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"p86l/internal/log"
+)
+
+const (
+	magic      = "BSDIFF40"
+	headerSize = 32
+	chunkSize  = 64 << 10
+)
+
+// offtin decodes the sign-magnitude integers of the format.
+func offtin(buf []byte) int64 {
+	n := int64(binary.LittleEndian.Uint64(buf) &^ (1 << 63))
+	if buf[7]&0x80 != 0 {
+		return -n
+	}
+	return n
+}
+
+// NewSize returns the size of the file patch produces.
+func NewSize(patch []byte) (int64, error) {
+	if len(patch) < headerSize || string(patch[:8]) != magic {
+		return 0, fmt.Errorf("%w: bad header", log.ErrPatchInvalid)
+	}
+	newSize := offtin(patch[24:32])
+	if newSize < 0 {
+		return 0, fmt.Errorf("%w: bad header", log.ErrPatchInvalid)
+	}
+	return newSize, nil
+}
+
+// Apply writes old patched by patch to dst. old is read at random, the new file is written in order.
+func Apply(dst io.Writer, old io.ReaderAt, oldSize int64, patch []byte) error {
+	newSize, err := NewSize(patch)
+	if err != nil {
+		return err
+	}
+	ctrlLen, diffLen := offtin(patch[8:16]), offtin(patch[16:24])
+	if ctrlLen < 0 || diffLen < 0 || headerSize+ctrlLen+diffLen > int64(len(patch)) {
+		return fmt.Errorf("%w: bad header", log.ErrPatchInvalid)
+	}
+
+	body := patch[headerSize:]
+	ctrl := bzip2.NewReader(bytes.NewReader(body[:ctrlLen]))
+	diff := bzip2.NewReader(bytes.NewReader(body[ctrlLen : ctrlLen+diffLen]))
+	extra := bzip2.NewReader(bytes.NewReader(body[ctrlLen+diffLen:]))
+
+	var triple [24]byte
+	buf := make([]byte, chunkSize)
+	oldBuf := make([]byte, chunkSize)
+
+	var newPos, oldPos int64
+	for newPos < newSize {
+		if _, err := io.ReadFull(ctrl, triple[:]); err != nil {
+			return fmt.Errorf("%w: control block: %w", log.ErrPatchInvalid, err)
+		}
+		add, copyLen, seek := offtin(triple[0:8]), offtin(triple[8:16]), offtin(triple[16:24])
+		if add < 0 || copyLen < 0 || newPos+add+copyLen > newSize {
+			return fmt.Errorf("%w: control block out of range", log.ErrPatchInvalid)
+		}
+
+		// Diff bytes are added to old ones, old bytes out of range count as zero.
+		for done := int64(0); done < add; {
+			n := min(add-done, chunkSize)
+			if _, err := io.ReadFull(diff, buf[:n]); err != nil {
+				return fmt.Errorf("%w: diff block: %w", log.ErrPatchInvalid, err)
+			}
+			if err := readOld(old, oldSize, oldPos+done, oldBuf[:n]); err != nil {
+				return err
+			}
+			for i := range n {
+				buf[i] += oldBuf[i]
+			}
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return err
+			}
+			done += n
+		}
+		newPos += add
+		oldPos += add
+
+		if _, err := io.CopyN(dst, extra, copyLen); err != nil {
+			return fmt.Errorf("%w: extra block: %w", log.ErrPatchInvalid, err)
+		}
+		newPos += copyLen
+		oldPos += seek
+	}
+
+	return nil
+}
+
+// readOld fills buf from old at off, zeroing what lies outside of it.
+func readOld(old io.ReaderAt, oldSize, off int64, buf []byte) error {
+	clear(buf)
+
+	start, end := max(off, 0), min(off+int64(len(buf)), oldSize)
+	if start >= end {
+		return nil
+	}
+	if _, err := old.ReadAt(buf[start-off:end-off], start); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package bsdiff_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"p86l/internal/bsdiff"
+	"p86l/internal/log"
+	"strings"
+	"testing"
+)
+
+const (
+	oldFile = "Project-86 build v0.1.0, old assets"
+	newFile = "Project-86 build v0.2.0, new assets!"
+	// bsdiff of oldFile to newFile, with a seek and a new file longer than the old one.
+	patchHex = "42534449464634302e0000000000000031000000000000002400000000000000425a68393141592653595ca3069300000b6000490228002000223d26d40c01b8d013b7c5dc914e14241728c1a4c0425a68393141592653594819aac3000000f000c800880020000020a0002129a31086039e811dbe2ee48a70a12090335586425a68393141592653597c892f990000029880000150000100200030cd3418c80b0e2ee48a70a120f9125f32"
+)
+
+func TestApply(t *testing.T) {
+	patch, err := hex.DecodeString(patchHex)
+	if err != nil {
+		t.Fatalf("failed to decode patch: %v", err)
+	}
+
+	size, err := bsdiff.NewSize(patch)
+	if err != nil || size != int64(len(newFile)) {
+		t.Fatalf("unexpected size: %d, %v", size, err)
+	}
+
+	var dst bytes.Buffer
+	if err := bsdiff.Apply(&dst, strings.NewReader(oldFile), int64(len(oldFile)), patch); err != nil {
+		t.Fatalf("failed to apply patch: %v", err)
+	}
+	if dst.String() != newFile {
+		t.Fatalf("unexpected result: %q", dst.String())
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	patch, err := hex.DecodeString(patchHex)
+	if err != nil {
+		t.Fatalf("failed to decode patch: %v", err)
+	}
+
+	for name, bad := range map[string][]byte{
+		"magic":     append([]byte("BSDIFF41"), patch[8:]...),
+		"truncated": patch[:len(patch)/2],
+		"short":     patch[:16],
+	} {
+		var dst bytes.Buffer
+		err := bsdiff.Apply(&dst, strings.NewReader(oldFile), int64(len(oldFile)), bad)
+		if !errors.Is(err, log.ErrPatchInvalid) {
+			t.Fatalf("%s: expected %v, got %v", name, log.ErrPatchInvalid, err)
+		}
+	}
+}
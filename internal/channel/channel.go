@@ -0,0 +1,153 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package channel describes the lines of releases the launcher installs side by side.
+package channel
+
+import (
+	"fmt"
+	"p86l/configs"
+	"p86l/internal/asset"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"path/filepath"
+	"strings"
+)
+
+// Filter picks the releases of a channel, empty fields match any.
+type Filter struct {
+	// asset.ChannelStable or asset.ChannelPreRelease.
+	Kind string `json:"kind,omitempty"`
+	// Glob on the tag name, or a regex when prefixed with "re:", like asset.Rule patterns.
+	Tag string `json:"tag,omitempty"`
+
+	// Tag compiled by Validate.
+	match func(string) bool
+}
+
+// Channel is a line of releases with its own build folder, e.g. a nightly channel
+// {"name": "nightly", "filter": {"kind": "prerelease", "tag": "*-nightly*"}}.
+type Channel struct {
+	Name   string `json:"name"`
+	Filter Filter `json:"filter"`
+	// Under configs.FolderBuilds, empty uses Name.
+	Folder string `json:"folder,omitempty"`
+}
+
+// State is what a channel has installed or is downloading.
+type State struct {
+	Installed string `json:"installed"`
+	// Download in progress/partial content.
+	Pending string `json:"pending"`
+}
+
+// Defaults keep the folders stable and pre-release builds always had.
+var Defaults = []Channel{
+	{Name: asset.ChannelStable, Filter: Filter{Kind: asset.ChannelStable}, Folder: configs.FolderStable},
+	{Name: asset.ChannelPreRelease, Filter: Filter{Kind: asset.ChannelPreRelease}, Folder: configs.FolderPreRelease},
+}
+
+// Path returns the build folder of the channel.
+func (c Channel) Path() string {
+	return filepath.Join(configs.FolderBuilds, c.folder())
+}
+
+func (c Channel) folder() string {
+	if c.Folder != "" {
+		return c.Folder
+	}
+	return c.Name
+}
+
+// Matches reports whether release belongs to the channel. A tag pattern is compiled by Validate,
+// until then it matches nothing.
+func (c Channel) Matches(release *github.RepositoryRelease) bool {
+	switch c.Filter.Kind {
+	case asset.ChannelStable:
+		if release.Prerelease {
+			return false
+		}
+	case asset.ChannelPreRelease:
+		if !release.Prerelease {
+			return false
+		}
+	}
+
+	if c.Filter.Tag == "" {
+		return true
+	}
+	return c.Filter.match != nil && c.Filter.match(release.TagName)
+}
+
+// Latest returns the first release of the channel out of releases sorted newest first, or nil.
+func (c Channel) Latest(releases []github.RepositoryRelease) *github.RepositoryRelease {
+	for i := range releases {
+		if c.Matches(&releases[i]) {
+			return &releases[i]
+		}
+	}
+	return nil
+}
+
+// Find returns the channel called name.
+func Find(channels []Channel, name string) (Channel, bool) {
+	for _, c := range channels {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Channel{}, false
+}
+
+// Validate checks names and folders are unique and folders stay a single entry of configs.FolderBuilds,
+// and compiles the tag patterns of channels in place.
+func Validate(channels []Channel) error {
+	names := make(map[string]bool, len(channels))
+	folders := make(map[string]bool, len(channels))
+
+	for i, c := range channels {
+		folder := strings.ToLower(c.folder())
+		switch {
+		case c.Name == "" || names[c.Name]:
+			return fmt.Errorf("%w: name %q", log.ErrChannelInvalid, c.Name)
+		case folders[folder] || !filepath.IsLocal(folder) || strings.ContainsAny(folder, `/\`) ||
+			folder == configs.FolderVersions || strings.HasSuffix(folder, configs.SuffixStaging) || strings.HasSuffix(folder, configs.SuffixBackup):
+			return fmt.Errorf("%w: folder %q", log.ErrChannelInvalid, c.folder())
+		case c.Filter.Kind != "" && c.Filter.Kind != asset.ChannelStable && c.Filter.Kind != asset.ChannelPreRelease:
+			return fmt.Errorf("%w: kind %q", log.ErrChannelInvalid, c.Filter.Kind)
+		}
+		if c.Filter.Tag != "" {
+			match, err := asset.Pattern(c.Filter.Tag)
+			if err != nil {
+				return fmt.Errorf("%w: %w", log.ErrChannelInvalid, err)
+			}
+			channels[i].Filter.match = match
+		}
+
+		names[c.Name] = true
+		folders[folder] = true
+	}
+
+	if len(channels) == 0 {
+		return fmt.Errorf("%w: no channels", log.ErrChannelInvalid)
+	}
+	return nil
+}
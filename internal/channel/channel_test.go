@@ -0,0 +1,108 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package channel_test
+
+import (
+	"errors"
+	"p86l/internal/channel"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"path/filepath"
+	"testing"
+)
+
+func TestLatest(t *testing.T) {
+	// Newest first, like ListReleases.
+	releases := []github.RepositoryRelease{
+		{TagName: "v0.3.0-nightly.2", Prerelease: true},
+		{TagName: "v0.3.0-rc.1", Prerelease: true},
+		{TagName: "v0.2.0"},
+		{TagName: "v0.1.0"},
+	}
+
+	channels := []channel.Channel{
+		{Name: "nightly", Filter: channel.Filter{Kind: "prerelease", Tag: "*-nightly*"}},
+		{Name: "rc", Filter: channel.Filter{Tag: `re:-rc\.\d+$`}},
+		{Name: "fork", Filter: channel.Filter{Tag: "fork-*"}},
+	}
+	if err := channel.Validate(channels); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	nightly, rc, none := channels[0], channels[1], channels[2]
+
+	tests := []struct {
+		channel channel.Channel
+		want    string
+	}{
+		{channel.Defaults[0], "v0.2.0"},
+		{channel.Defaults[1], "v0.3.0-nightly.2"},
+		{nightly, "v0.3.0-nightly.2"},
+		{rc, "v0.3.0-rc.1"},
+	}
+	for _, tt := range tests {
+		latest := tt.channel.Latest(releases)
+		if latest == nil || latest.TagName != tt.want {
+			t.Fatalf("%s: expected %s, got %v", tt.channel.Name, tt.want, latest)
+		}
+	}
+
+	if latest := none.Latest(releases); latest != nil {
+		t.Fatalf("unexpected release: %s", latest.TagName)
+	}
+}
+
+func TestUnvalidated(t *testing.T) {
+	nightly := channel.Channel{Name: "nightly", Filter: channel.Filter{Tag: "*-nightly*"}}
+	if nightly.Matches(&github.RepositoryRelease{TagName: "v0.3.0-nightly.1"}) {
+		t.Fatalf("tag pattern matched before Validate")
+	}
+}
+
+func TestPath(t *testing.T) {
+	if path := channel.Defaults[0].Path(); path != filepath.Join("builds", "stable") {
+		t.Fatalf("unexpected path: %s", path)
+	}
+	if path := (channel.Channel{Name: "nightly"}).Path(); path != filepath.Join("builds", "nightly") {
+		t.Fatalf("unexpected path: %s", path)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := channel.Validate(channel.Defaults); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, channels := range map[string][]channel.Channel{
+		"empty":     nil,
+		"no name":   {{Folder: "a"}},
+		"duplicate": {{Name: "a"}, {Name: "a", Folder: "b"}},
+		"folder":    {{Name: "a", Folder: "stable"}, {Name: "b", Folder: "Stable"}},
+		"escape":    {{Name: "a", Folder: "../a"}},
+		"versions":  {{Name: "versions"}},
+		"kind":      {{Name: "a", Filter: channel.Filter{Kind: "nightly"}}},
+		"pattern":   {{Name: "a", Filter: channel.Filter{Tag: "re:("}}},
+	} {
+		if err := channel.Validate(channels); !errors.Is(err, log.ErrChannelInvalid) {
+			t.Fatalf("%s: expected %v, got %v", name, log.ErrChannelInvalid, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package checksum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	gnuLine = regexp.MustCompile(`^([0-9a-fA-F]{64})(?:\s+\*?(\S.*))?$`)
+	bsdLine = regexp.MustCompile(`^SHA256\s*\((.+)\)\s*=\s*([0-9a-fA-F]{64})$`)
+)
+
+// Parse reads sha256 digests keyed by file name from sha256sum ("<hex>  <name>"),
+// BSD ("SHA256 (<name>) = <hex>") or bare "<hex>" lines, the latter keyed by "".
+// Other lines are ignored, so release notes can be parsed as well.
+func Parse(text string) map[string]string {
+	sums := make(map[string]string)
+
+	for line := range strings.Lines(text) {
+		// Markdown list items and inline code.
+		line = strings.TrimLeft(strings.TrimSpace(line), "-* ")
+		line = strings.TrimSpace(strings.Trim(line, "`"))
+
+		if match := gnuLine.FindStringSubmatch(line); match != nil {
+			sums[path.Base(strings.TrimSpace(match[2]))] = strings.ToLower(match[1])
+			continue
+		}
+		if match := bsdLine.FindStringSubmatch(line); match != nil {
+			sums[path.Base(strings.TrimSpace(match[1]))] = strings.ToLower(match[2])
+		}
+	}
+
+	// path.Base("") is ".".
+	if sum, ok := sums["."]; ok {
+		delete(sums, ".")
+		sums[""] = sum
+	}
+
+	return sums
+}
+
+// IsSumsFile reports whether a release asset holds checksums. which is the name
+// of the only file it covers, or empty for a list of checksums.
+func IsSumsFile(name string) (which string, ok bool) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".sha256"):
+		return name[:len(name)-len(".sha256")], true
+	case strings.HasSuffix(lower, ".sha256sum"):
+		return name[:len(name)-len(".sha256sum")], true
+	case lower == "sha256sums", lower == "sha256sums.txt", lower == "sha256sum.txt", lower == "checksums.txt":
+		return "", true
+	}
+	return "", false
+}
+
+// SHA256 returns the lowercase hex digest of r.
+func SHA256(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Normalize strips the "sha256:" prefix GitHub uses for asset digests and lowercases the hex.
+func Normalize(digest string) string {
+	digest = strings.TrimSpace(digest)
+	digest = strings.TrimPrefix(strings.ToLower(digest), "sha256:")
+	return digest
+}
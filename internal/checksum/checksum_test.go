@@ -0,0 +1,76 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package checksum_test
+
+import (
+	"p86l/internal/checksum"
+	"strings"
+	"testing"
+)
+
+const digest = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func TestParse(t *testing.T) {
+	sums := checksum.Parse(strings.Join([]string{
+		"## Checksums",
+		digest + "  Project86-v0.1.0.zip",
+		strings.ToUpper(digest) + " *bin/Project86-v0.1.0-linux.zip",
+		"SHA256 (Project86-v0.1.0-macOS.zip) = " + digest,
+		"- `" + digest + "  Project86-v0.1.0-dev.zip`",
+		"not a checksum line",
+	}, "\n"))
+
+	for _, name := range []string{"Project86-v0.1.0.zip", "Project86-v0.1.0-linux.zip", "Project86-v0.1.0-macOS.zip", "Project86-v0.1.0-dev.zip"} {
+		if sums[name] != digest {
+			t.Fatalf("unexpected digest for %s: %q", name, sums[name])
+		}
+	}
+	if len(sums) != 4 {
+		t.Fatalf("unexpected sums: %v", sums)
+	}
+
+	if sums := checksum.Parse(digest + "\n"); sums[""] != digest {
+		t.Fatalf("unexpected bare digest: %v", sums)
+	}
+}
+
+func TestIsSumsFile(t *testing.T) {
+	if which, ok := checksum.IsSumsFile("Project86-v0.1.0.zip.sha256"); !ok || which != "Project86-v0.1.0.zip" {
+		t.Fatalf("unexpected result: %s, %v", which, ok)
+	}
+	if which, ok := checksum.IsSumsFile("SHA256SUMS"); !ok || which != "" {
+		t.Fatalf("unexpected result: %s, %v", which, ok)
+	}
+	if _, ok := checksum.IsSumsFile("Project86-v0.1.0.zip"); ok {
+		t.Fatal("zip is not a sums file")
+	}
+}
+
+func TestSHA256(t *testing.T) {
+	sum, err := checksum.SHA256(strings.NewReader("test"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if sum != digest || checksum.Normalize("sha256:"+strings.ToUpper(digest)) != digest {
+		t.Fatalf("unexpected digest: %s", sum)
+	}
+}
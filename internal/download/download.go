@@ -0,0 +1,436 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"p86l/internal/log"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultConnections = 4
+	MaxConnections     = 16
+	// Smaller files are not worth another connection.
+	MinSegmentSize = 1 << 20
+
+	// Saved next to the file while segments are incomplete, so a later run can resume them.
+	StateSuffix = ".segments"
+
+	stateSaveInterval = time.Second
+)
+
+// defaultClient gives up on servers that stop answering. It has no overall timeout,
+// a whole archive takes longer than any sensible one.
+var defaultClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConnsPerHost:   MaxConnections,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+	},
+}
+
+type Config struct {
+	Client      *http.Client
+	Connections int
+	Limiter     *Limiter
+}
+
+// Option changes how New builds the downloader.
+type Option func(*Config)
+
+func WithClient(client *http.Client) Option {
+	return func(c *Config) {
+		if client != nil {
+			c.Client = client
+		}
+	}
+}
+
+// WithConnections sets how many segments are downloaded at once, clamped to [1, MaxConnections].
+func WithConnections(connections int) Option {
+	return func(c *Config) {
+		c.Connections = min(max(connections, 1), MaxConnections)
+	}
+}
+
+// WithLimiter shares limiter between every segment, nil is unlimited.
+func WithLimiter(limiter *Limiter) Option {
+	return func(c *Config) {
+		c.Limiter = limiter
+	}
+}
+
+type Downloader struct {
+	config Config
+}
+
+func New(opts ...Option) *Downloader {
+	config := Config{
+		Client:      defaultClient,
+		Connections: DefaultConnections,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &Downloader{config: config}
+}
+
+type segment struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"` // exclusive
+	Done  int64 `json:"done"`
+
+	done atomic.Int64
+}
+
+func (s *segment) remaining() int64 {
+	return s.End - s.Start - s.done.Load()
+}
+
+type state struct {
+	Size     int64      `json:"size"`
+	Segments []*segment `json:"segments"`
+}
+
+// Response reports the progress of a download, like grab.Response.
+type Response struct {
+	// Closed once the download finished or failed.
+	Done chan struct{}
+
+	size     int64
+	resumed  int64
+	start    time.Time
+	segments []*segment
+	err      error
+}
+
+func (r *Response) BytesComplete() int64 {
+	var total int64
+	for _, s := range r.segments {
+		total += s.done.Load()
+	}
+	return total
+}
+
+func (r *Response) Size() int64 {
+	return r.size
+}
+
+// BytesPerSecond is the average rate since the download (re)started.
+func (r *Response) BytesPerSecond() float64 {
+	elapsed := time.Since(r.start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(r.BytesComplete()-r.resumed) / elapsed
+}
+
+func (r *Response) ETA() time.Time {
+	rate := r.BytesPerSecond()
+	if rate <= 0 {
+		return time.Time{}
+	}
+	remaining := float64(r.size - r.BytesComplete())
+	return time.Now().Add(time.Duration(remaining / rate * float64(time.Second)))
+}
+
+// Err blocks until the download is done.
+func (r *Response) Err() error {
+	<-r.Done
+	return r.err
+}
+
+// probe asks for the first byte. Only a 206 with the total size in Content-Range counts as
+// range support, servers ignoring Range answer 200 even when they advertise Accept-Ranges.
+func (d *Downloader) probe(ctx context.Context, url string) (string, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Range", "bytes=0-0")
+
+	resp, err := d.config.Client.Do(req)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		return "", 0, log.ErrDownloadNoRanges
+	default:
+		return "", 0, fmt.Errorf("%w: %s", log.ErrDownloadStatus, resp.Status)
+	}
+
+	_, total, ok := strings.Cut(resp.Header.Get("Content-Range"), "/")
+	size, err := strconv.ParseInt(total, 10, 64)
+	if !ok || err != nil || size <= 0 {
+		return "", 0, log.ErrDownloadNoRanges
+	}
+
+	// Later requests skip the redirect to the CDN.
+	return resp.Request.URL.String(), size, nil
+}
+
+// split divides [from, size) into at most connections segments, [0, from) is already done.
+func split(from, size int64, connections int) []*segment {
+	var segments []*segment
+	if from > 0 {
+		s := &segment{Start: 0, End: from}
+		s.done.Store(from)
+		segments = append(segments, s)
+	}
+
+	remaining := size - from
+	count := int64(min(max(connections, 1), int((remaining+MinSegmentSize-1)/MinSegmentSize)))
+	for i := range count {
+		start := from + remaining*i/count
+		end := from + remaining*(i+1)/count
+		segments = append(segments, &segment{Start: start, End: end})
+	}
+
+	return segments
+}
+
+// plan resumes the segments of a previous run, or continues a partial single stream download.
+func plan(dst string, size int64, connections int) []*segment {
+	info, err := os.Stat(dst)
+	if err != nil {
+		return split(0, size, connections)
+	}
+
+	if data, err := os.ReadFile(dst + StateSuffix); err == nil {
+		var saved state
+		if json.Unmarshal(data, &saved) == nil && saved.Size == size && info.Size() == size {
+			for _, s := range saved.Segments {
+				s.done.Store(min(max(s.Done, 0), s.End-s.Start))
+			}
+			return saved.Segments
+		}
+		// Unknown progress, start over.
+		return split(0, size, connections)
+	}
+
+	if info.Size() <= size {
+		return split(info.Size(), size, connections)
+	}
+	return split(0, size, connections)
+}
+
+// Remaining returns the bytes a resumed download of dst still has to fetch, from the state a
+// segmented run saved. The file itself is preallocated to full size, it says nothing about progress.
+func Remaining(dst string) (int64, error) {
+	data, err := os.ReadFile(dst + StateSuffix)
+	if err != nil {
+		return 0, err
+	}
+	var saved state
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return 0, err
+	}
+
+	var remaining int64
+	for _, s := range saved.Segments {
+		remaining += s.End - s.Start - min(max(s.Done, 0), s.End-s.Start)
+	}
+	return remaining, nil
+}
+
+func saveState(dst string, size int64, segments []*segment) error {
+	for _, s := range segments {
+		s.Done = s.done.Load()
+	}
+	data, err := json.Marshal(state{Size: size, Segments: segments})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst+StateSuffix, data, 0644)
+}
+
+// Do downloads url into dst with ranged requests, resuming what a previous run left.
+// It fails with log.ErrDownloadNoRanges when the server does not support them, and with
+// log.ErrDownloadSize when size is set and differs from the remote file.
+func (d *Downloader) Do(ctx context.Context, url, dst string, size int64) (*Response, error) {
+	finalURL, remoteSize, err := d.probe(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if size > 0 && size != remoteSize {
+		return nil, fmt.Errorf("%w: expected %d, got %d", log.ErrDownloadSize, size, remoteSize)
+	}
+
+	segments := plan(dst, remoteSize, d.config.Connections)
+
+	// The state goes first, a full size file without it would look complete.
+	if err := saveState(dst, remoteSize, segments); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(dst, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Truncate(remoteSize); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	resp := &Response{
+		Done:     make(chan struct{}),
+		size:     remoteSize,
+		start:    time.Now(),
+		segments: segments,
+	}
+	resp.resumed = resp.BytesComplete()
+
+	go d.run(ctx, finalURL, dst, file, resp)
+
+	return resp, nil
+}
+
+func (d *Downloader) run(ctx context.Context, url, dst string, file *os.File, resp *Response) {
+	defer close(resp.Done)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	fail := func(err error) {
+		errOnce.Do(func() {
+			resp.err = err
+			cancel()
+		})
+	}
+
+	for _, s := range resp.segments {
+		if s.remaining() <= 0 {
+			continue
+		}
+		wg.Go(func() {
+			if err := d.fetch(ctx, url, file, s); err != nil {
+				fail(err)
+			}
+		})
+	}
+
+	stopSaving := make(chan struct{})
+	saverDone := make(chan struct{})
+	go func() {
+		defer close(saverDone)
+		t := time.NewTicker(stateSaveInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				// Best effort, the final state is saved below.
+				_ = saveState(dst, resp.size, resp.segments)
+			case <-stopSaving:
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	// A save still running could race the final one, or bring the state back after it is removed.
+	close(stopSaving)
+	<-saverDone
+
+	if err := file.Close(); err != nil && resp.err == nil {
+		resp.err = err
+	}
+	if resp.err != nil {
+		_ = saveState(dst, resp.size, resp.segments)
+		return
+	}
+	if err := os.Remove(dst + StateSuffix); err != nil && !os.IsNotExist(err) {
+		resp.err = err
+	}
+}
+
+func (d *Downloader) fetch(ctx context.Context, url string, file *os.File, s *segment) error {
+	offset := s.Start + s.done.Load()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, s.End-1))
+
+	resp, err := d.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("%w: %s", log.ErrDownloadStatus, resp.Status)
+	}
+
+	buf := make([]byte, 32<<10)
+	for offset < s.End {
+		n, err := resp.Body.Read(buf[:min(int64(len(buf)), s.End-offset)])
+		if n > 0 {
+			if _, wErr := file.WriteAt(buf[:n], offset); wErr != nil {
+				return wErr
+			}
+			offset += int64(n)
+			s.done.Add(int64(n))
+
+			if d.config.Limiter != nil {
+				if wErr := d.config.Limiter.WaitN(ctx, n); wErr != nil {
+					return wErr
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if offset != s.End {
+		return fmt.Errorf("%w: segment %d-%d ended at %d", log.ErrDownloadSize, s.Start, s.End, offset)
+	}
+	return nil
+}
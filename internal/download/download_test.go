@@ -0,0 +1,219 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"p86l/internal/download"
+	"p86l/internal/log"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newServer(t *testing.T, ranges bool) ([]byte, string, *atomic.Int32) {
+	t.Helper()
+
+	content := make([]byte, 5*download.MinSegmentSize+123)
+	for i := range content {
+		content[i] = byte(rand.IntN(256))
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !ranges {
+			r.Header.Del("Range")
+		}
+		http.ServeContent(w, r, "game.zip", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+
+	return content, server.URL + "/game.zip", &requests
+}
+
+func check(t *testing.T, dst string, content []byte) {
+	t.Helper()
+
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatalf("failed to read download: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("download differs from content")
+	}
+	if _, err := os.Stat(dst + download.StateSuffix); !os.IsNotExist(err) {
+		t.Fatalf("state file left behind: %v", err)
+	}
+}
+
+func TestDo(t *testing.T) {
+	content, url, requests := newServer(t, true)
+	dst := filepath.Join(t.TempDir(), "game.zip")
+
+	resp, err := download.New(download.WithConnections(4)).Do(context.Background(), url, dst, int64(len(content)))
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if err := resp.Err(); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if resp.BytesComplete() != int64(len(content)) {
+		t.Fatalf("unexpected bytes complete: %d", resp.BytesComplete())
+	}
+	check(t, dst, content)
+
+	// One probe and four segments.
+	if got := requests.Load(); got != 5 {
+		t.Fatalf("unexpected request count: %d", got)
+	}
+}
+
+func TestResume(t *testing.T) {
+	content, url, _ := newServer(t, true)
+	dst := filepath.Join(t.TempDir(), "game.zip")
+
+	// Left by a single stream download.
+	if err := os.WriteFile(dst, content[:download.MinSegmentSize+7], 0644); err != nil {
+		t.Fatalf("failed to write partial file: %v", err)
+	}
+
+	resp, err := download.New().Do(context.Background(), url, dst, 0)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if err := resp.Err(); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	check(t, dst, content)
+}
+
+func TestNoRanges(t *testing.T) {
+	content, url, _ := newServer(t, false)
+	dst := filepath.Join(t.TempDir(), "game.zip")
+
+	if _, err := download.New().Do(context.Background(), url, dst, int64(len(content))); !errors.Is(err, log.ErrDownloadNoRanges) {
+		t.Fatalf("expected no ranges error, got %v", err)
+	}
+}
+
+func TestSizeMismatch(t *testing.T) {
+	content, url, _ := newServer(t, true)
+	dst := filepath.Join(t.TempDir(), "game.zip")
+
+	if _, err := download.New().Do(context.Background(), url, dst, int64(len(content))+1); !errors.Is(err, log.ErrDownloadSize) {
+		t.Fatalf("expected size error, got %v", err)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	limiter := download.NewLimiter(1 << 20)
+
+	start := time.Now()
+	for range 4 {
+		if err := limiter.WaitN(context.Background(), 128<<10); err != nil {
+			t.Fatalf("WaitN failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 450*time.Millisecond || elapsed > 2*time.Second {
+		t.Fatalf("unexpected elapsed time: %v", elapsed)
+	}
+
+	limiter.SetLimit(0)
+	start = time.Now()
+	if err := limiter.WaitN(context.Background(), 1<<30); err != nil || time.Since(start) > 100*time.Millisecond {
+		t.Fatalf("unlimited WaitN blocked: %v", err)
+	}
+
+	limiter.SetLimit(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.WaitN(ctx, 1<<10); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+}
+
+func TestLimitedDo(t *testing.T) {
+	content, url, _ := newServer(t, true)
+	dst := filepath.Join(t.TempDir(), "game.zip")
+
+	limiter := download.NewLimiter(int64(len(content)) * 2)
+	start := time.Now()
+	resp, err := download.New(download.WithLimiter(limiter)).Do(context.Background(), url, dst, 0)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if err := resp.Err(); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("download was not limited: %v", elapsed)
+	}
+	check(t, dst, content)
+}
+
+func TestCancelResume(t *testing.T) {
+	content, url, _ := newServer(t, true)
+	dst := filepath.Join(t.TempDir(), "game.zip")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	limiter := download.NewLimiter(int64(len(content)) / 2)
+	resp, err := download.New(download.WithLimiter(limiter)).Do(ctx, url, dst, 0)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+
+	if err := resp.Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+	if _, err := os.Stat(dst + download.StateSuffix); err != nil {
+		t.Fatalf("state file missing after cancel: %v", err)
+	}
+	remaining, err := download.Remaining(dst)
+	if err != nil {
+		t.Fatalf("Remaining failed: %v", err)
+	}
+	if remaining <= 0 || remaining >= int64(len(content)) {
+		t.Fatalf("expected a partial remainder, got %d of %d", remaining, len(content))
+	}
+
+	resp, err = download.New().Do(context.Background(), url, dst, 0)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.BytesComplete() == 0 {
+		t.Fatalf("download did not resume")
+	}
+	if err := resp.Err(); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	check(t, dst, content)
+}
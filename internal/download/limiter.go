@@ -0,0 +1,81 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package download
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter caps the combined rate of every download using it, it satisfies grab.RateLimiter.
+// The limit can be changed while downloads are running.
+type Limiter struct {
+	mu    sync.Mutex
+	limit int64
+	next  time.Time
+}
+
+// NewLimiter returns a limiter of bytesPerSecond, 0 is unlimited.
+func NewLimiter(bytesPerSecond int64) *Limiter {
+	return &Limiter{limit: max(bytesPerSecond, 0)}
+}
+
+func (l *Limiter) Limit() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+func (l *Limiter) SetLimit(bytesPerSecond int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = max(bytesPerSecond, 0)
+	// Forget what was reserved at the old rate.
+	l.next = time.Time{}
+}
+
+// WaitN blocks until n more bytes fit in the limit.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	l.mu.Lock()
+	if l.limit <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(int64(n) * int64(time.Second) / l.limit))
+	wait := l.next.Sub(now)
+	l.mu.Unlock()
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
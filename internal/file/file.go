@@ -92,6 +92,11 @@ func (f *Filesystem) Path() string {
 	return f.path
 }
 
+// FreeSpace returns the bytes available on the volume holding Path.
+func (f *Filesystem) FreeSpace() (uint64, error) {
+	return freeSpace(f.path)
+}
+
 func (f *Filesystem) Remove(filePath string) error {
 	err := f.root.Remove(filePath)
 	if err != nil {
@@ -139,6 +144,37 @@ func (f *Filesystem) Save(filePath string, fileBytes []byte) error {
 	return nil
 }
 
+// Copy writes the content of src to a new dst with the same permissions.
+// Unlike a hard link, writing to dst later leaves src as it was.
+func (f *Filesystem) Copy(src, dst string) error {
+	in, err := f.root.Open(src)
+	if err != nil {
+		return fmt.Errorf("%w: %w", log.ErrFileCopy, err)
+	}
+	defer func() { _ = in.Close() }()
+
+	info, err := in.Stat()
+	if err != nil {
+		return fmt.Errorf("%w: %w", log.ErrFileCopy, err)
+	}
+
+	// A file already at dst may be linked elsewhere, it is replaced rather than written through.
+	if err := f.root.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %w", log.ErrFileCopy, err)
+	}
+	out, err := f.root.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("%w: %w", log.ErrFileCopy, err)
+	}
+	defer func() { _ = out.Close() }()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("%w: %w", log.ErrFileCopy, err)
+	}
+
+	return nil
+}
+
 func (f *Filesystem) Close() error {
 	return f.root.Close()
 }
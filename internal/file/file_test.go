@@ -78,3 +78,49 @@ func TestLoadFile(t *testing.T) {
 		t.Fatalf("%v", err)
 	}
 }
+
+func TestFreeSpace(t *testing.T) {
+	fs := setup(t)
+	defer func() {
+		err := fs.Close()
+		if err != nil {
+			t.Fatalf("Failed to close fs: %v", err)
+		}
+	}()
+
+	free, err := fs.FreeSpace()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if free == 0 {
+		t.Fatal("no free space reported")
+	}
+}
+
+func TestCopyFile(t *testing.T) {
+	fs := setup(t)
+	defer func() {
+		err := fs.Close()
+		if err != nil {
+			t.Fatalf("Failed to close fs: %v", err)
+		}
+	}()
+
+	// test.txt stands for a file of the backup, test-copy.txt for the installed one.
+	err := fs.Copy("test.txt", "test-copy.txt")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	err = fs.Save("test-copy.txt", []byte("changed"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	value, err := fs.Load("test.txt")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if string(value) != "test" {
+		t.Fatalf("writing to the copy changed the original: %q", value)
+	}
+}
@@ -0,0 +1,38 @@
+//go:build netbsd || solaris
+
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package file
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// available returns the bytes of the volume holding path that unprivileged users can write.
+func available(path string) (uint64, error) {
+	var stat unix.Statvfs_t
+	if err := unix.Statvfs(path, &stat); err != nil {
+		return 0, err
+	}
+	// Blocks are counted in fragments.
+	return stat.Bavail * stat.Frsize, nil
+}
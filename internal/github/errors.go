@@ -0,0 +1,146 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"p86l/internal/log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is returned for any non-200 response, it unwraps to log.ErrGithubRequestStatus.
+type APIError struct {
+	StatusCode       int
+	Status           string
+	Message          string
+	DocumentationURL string
+	// From the X-RateLimit-* headers, nil when the response had none.
+	RateLimit *RateLimitCore
+	// From the Retry-After header, zero when the response had none.
+	RetryAfter time.Duration
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RateLimit:  rateLimitFrom(resp.Header),
+		RetryAfter: retryAfterFrom(resp.Header),
+	}
+
+	var msg struct {
+		Message          string `json:"message"`
+		DocumentationURL string `json:"documentation_url"`
+	}
+	if json.Unmarshal(body, &msg) == nil {
+		apiErr.Message = msg.Message
+		apiErr.DocumentationURL = msg.DocumentationURL
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("%v: %s, %s", log.ErrGithubRequestStatus, e.Status, e.Message)
+	}
+	return fmt.Sprintf("%v: %s", log.ErrGithubRequestStatus, e.Status)
+}
+
+func (e *APIError) Unwrap() error {
+	return log.ErrGithubRequestStatus
+}
+
+// IsRateLimit reports whether the primary rate limit ran out.
+func (e *APIError) IsRateLimit() bool {
+	if e.StatusCode != http.StatusForbidden && e.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return e.RateLimit != nil && e.RateLimit.Remaining == 0
+}
+
+// IsSecondaryRateLimit reports whether GitHub throttled us for abuse,
+// these come with Retry-After or only mention it in the message.
+func (e *APIError) IsSecondaryRateLimit() bool {
+	if e.StatusCode != http.StatusForbidden && e.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return e.RetryAfter > 0 || strings.Contains(strings.ToLower(e.Message), "secondary rate limit")
+}
+
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= http.StatusInternalServerError
+}
+
+// RetryDelay returns how long GitHub asked us to wait, zero when it did not say.
+func (e *APIError) RetryDelay() time.Duration {
+	if e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+	if e.IsRateLimit() && e.RateLimit.Reset > 0 {
+		return max(time.Until(time.Unix(e.RateLimit.Reset, 0)), 0)
+	}
+	if e.IsSecondaryRateLimit() {
+		// Documented minimum when no header is given.
+		return time.Minute
+	}
+	return 0
+}
+
+// rateLimitFrom parses the X-RateLimit-* headers, nil when they are missing.
+func rateLimitFrom(header http.Header) *RateLimitCore {
+	limit, err1 := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	remaining, err2 := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	reset, err3 := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil
+	}
+
+	return &RateLimitCore{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     reset,
+	}
+}
+
+// retryAfterFrom parses Retry-After, either in seconds or as a http date.
+func retryAfterFrom(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
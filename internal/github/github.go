@@ -27,8 +27,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"p86l/internal/log"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -59,41 +61,133 @@ type ReleaseAsset struct {
 	BrowserDownloadURL string `json:"browser_download_url"`
 	Size               int64  `json:"size"`
 	DownloadCount      int64  `json:"download_count"`
+	Digest             string `json:"digest"` // "sha256:<hex>", empty on older releases
 }
 
-type LatestReleases struct {
-	PreRelease *RepositoryRelease `json:"pre_release"`
-	Stable     *RepositoryRelease `json:"stable"`
+const (
+	releasesPerPage  = 100
+	maxReleasesPages = 10
+
+	DefaultBaseURL   = "https://api.github.com"
+	DefaultTimeout   = 15 * time.Second
+	DefaultUserAgent = "p86l"
+)
+
+// Validators are the cache headers of a previous response, sent back so
+// GitHub can answer with 304 Not Modified, which does not count against the rate limit.
+type Validators struct {
+	ETag         string
+	LastModified string
 }
 
 type Config struct {
-	Timeout time.Duration
+	BaseURL   string
+	Transport http.RoundTripper
+	Timeout   time.Duration
+	UserAgent string
+	Token     string
+	// Called with the X-RateLimit-* headers of every response that has them.
+	OnRateLimit func(*RateLimitCore)
+}
+
+// Option changes how NewClient builds the client.
+type Option func(*Config)
+
+// WithBaseURL points the client at another API, e.g. a GitHub Enterprise mirror or httptest server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Config) {
+		if baseURL != "" {
+			c.BaseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithTransport replaces the transport used by the underlying http.Client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = transport
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		if timeout > 0 {
+			c.Timeout = timeout
+		}
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Config) {
+		if userAgent != "" {
+			c.UserAgent = userAgent
+		}
+	}
+}
+
+// WithToken authenticates requests with a personal access token, raising the rate limit to 5000/hour.
+func WithToken(token string) Option {
+	return func(c *Config) {
+		c.Token = strings.TrimSpace(token)
+	}
+}
+
+// WithRateLimitFunc keeps the caller up to date with the rate limit, without polling /rate_limit.
+func WithRateLimitFunc(fn func(*RateLimitCore)) Option {
+	return func(c *Config) {
+		c.OnRateLimit = fn
+	}
 }
 
 type Client struct {
+	config     Config
 	httpClient *http.Client
 }
 
-func NewClient() *Client {
+func NewClient(opts ...Option) *Client {
+	config := Config{
+		BaseURL:   DefaultBaseURL,
+		Timeout:   DefaultTimeout,
+		UserAgent: DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	return &Client{
+		config: config,
 		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
+			Transport: config.Transport,
+			Timeout:   config.Timeout,
 		},
 	}
 }
 
 func (c *Client) BaseURL() string {
-	return "https://api.github.com"
+	return c.config.BaseURL
 }
 
-func (c *Client) doRequest(ctx context.Context, method, path string) ([]byte, error) {
-	url := c.BaseURL() + path
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+// Authenticated reports whether requests carry a token.
+func (c *Client) Authenticated() bool {
+	return c.config.Token != ""
+}
+
+func (c *Client) doRequest(ctx context.Context, method, path string, validators Validators) ([]byte, http.Header, error) {
+	return c.doRequestURL(ctx, method, c.BaseURL()+path, validators)
+}
+
+// Get fetches an absolute url with the client's headers, for GitHub compatible or static servers.
+func (c *Client) Get(ctx context.Context, url string, validators Validators) ([]byte, http.Header, error) {
+	return c.doRequestURL(ctx, "GET", url, validators)
+}
+
+// Download fetches a release asset, like a checksum file, reading at most limit bytes of it.
+func (c *Client) Download(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := c.newRequest(ctx, "GET", url)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", log.ErrGithubRequestNew, err)
+		return nil, err
 	}
-
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Accept", "application/octet-stream")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -102,10 +196,11 @@ func (c *Client) doRequest(ctx context.Context, method, path string) ([]byte, er
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%w: %d, %s", log.ErrGithubRequestStatus, resp.StatusCode, resp.Status)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return nil, newAPIError(resp, body)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit))
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", log.ErrGithubRequestBodyRead, err)
 	}
@@ -113,62 +208,141 @@ func (c *Client) doRequest(ctx context.Context, method, path string) ([]byte, er
 	return body, nil
 }
 
-func (c *Client) GetRateLimit(ctx context.Context) (*RateLimitCore, error) {
-	data, err := c.doRequest(ctx, "GET", "/rate_limit")
+// newRequest sets the headers shared by every request, the token is dropped by net/http on redirects to other hosts.
+func (c *Client) newRequest(ctx context.Context, method, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", log.ErrGithubRequestNew, err)
 	}
 
-	var rateLimitResp RateLimits
-	if err := json.Unmarshal(data, &rateLimitResp); err != nil {
-		return nil, fmt.Errorf("parsing rate limit data: %w", err)
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	// Asset urls may point anywhere, the token only goes to the API it is for.
+	if c.Authenticated() && c.isAPI(req.URL) {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
 	}
+	return req, nil
+}
 
-	return &rateLimitResp.Resources.Core, nil
+// isAPI reports whether u is on the scheme and host of BaseURL.
+func (c *Client) isAPI(u *url.URL) bool {
+	base, err := url.Parse(c.config.BaseURL)
+	return err == nil && u.Scheme == base.Scheme && u.Host == base.Host
 }
 
-func (c *Client) getReleases(ctx context.Context, owner, repo string) ([]RepositoryRelease, error) {
-	path := fmt.Sprintf("/repos/%s/%s/releases", owner, repo)
-	data, err := c.doRequest(ctx, "GET", path)
+// doRequestURL is doRequest for absolute urls, e.g. the ones from a Link header.
+func (c *Client) doRequestURL(ctx context.Context, method, url string, validators Validators) ([]byte, http.Header, error) {
+	req, err := c.newRequest(ctx, method, url)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var releases []RepositoryRelease
-	if err := json.Unmarshal(data, &releases); err != nil {
-		return nil, fmt.Errorf("parsing releases data: %w", err)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
 	}
 
-	// Sort by published date (newest first)
-	sort.Slice(releases, func(i, j int) bool {
-		return releases[i].PublishedAt.After(releases[j].PublishedAt)
-	})
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", log.ErrGithubRequestDo, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if rl := rateLimitFrom(resp.Header); rl != nil && c.config.OnRateLimit != nil {
+		c.config.OnRateLimit(rl)
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, resp.Header, log.ErrGithubNotModified
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// The body only matters for the error message, a failed read leaves it empty.
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return nil, resp.Header, newAPIError(resp, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.Header, fmt.Errorf("%w: %w", log.ErrGithubRequestBodyRead, err)
+	}
 
-	return releases, nil
+	return body, resp.Header, nil
 }
 
-func (c *Client) GetLatestReleases(ctx context.Context, owner, repo string) (*LatestReleases, error) {
-	releases, err := c.getReleases(ctx, owner, repo)
+func validatorsFrom(header http.Header) Validators {
+	return Validators{
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+	}
+}
+
+// nextPageURL returns the rel="next" target of a Link header, or empty on the last page.
+func nextPageURL(header http.Header) string {
+	for _, link := range header.Values("Link") {
+		for part := range strings.SplitSeq(link, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+			if !ok {
+				continue
+			}
+			for param := range strings.SplitSeq(params, ";") {
+				if strings.TrimSpace(param) == `rel="next"` {
+					return strings.Trim(strings.TrimSpace(target), "<>")
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// GetRateLimit polls /rate_limit, which is free but mostly redundant with WithRateLimitFunc.
+func (c *Client) GetRateLimit(ctx context.Context) (*RateLimitCore, error) {
+	data, _, err := c.doRequest(ctx, "GET", "/rate_limit", Validators{})
 	if err != nil {
 		return nil, err
 	}
 
-	result := &LatestReleases{}
+	var rateLimitResp RateLimits
+	if err := json.Unmarshal(data, &rateLimitResp); err != nil {
+		return nil, fmt.Errorf("parsing rate limit data: %w", err)
+	}
+
+	return &rateLimitResp.Resources.Core, nil
+}
+
+// ListReleases returns every release of the repository, newest first, following Link pagination.
+// Validators only apply to the first page, log.ErrGithubNotModified is returned when they still match.
+func (c *Client) ListReleases(ctx context.Context, owner, repo string, validators Validators) ([]RepositoryRelease, Validators, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases?per_page=%d", c.BaseURL(), owner, repo, releasesPerPage)
 
-	for i := range releases {
-		release := &releases[i]
+	var releases []RepositoryRelease
+	var respValidators Validators
 
-		if release.Prerelease && result.PreRelease == nil {
-			result.PreRelease = release
-		} else if !release.Prerelease && result.Stable == nil {
-			result.Stable = release
+	for page := 0; url != "" && page < maxReleasesPages; page++ {
+		data, header, err := c.doRequestURL(ctx, "GET", url, validators)
+		if page == 0 {
+			respValidators = validatorsFrom(header)
+			validators = Validators{}
+		}
+		if err != nil {
+			return nil, respValidators, err
 		}
 
-		// Exit early if we found both
-		if result.PreRelease != nil && result.Stable != nil {
-			break
+		var pageReleases []RepositoryRelease
+		if err := json.Unmarshal(data, &pageReleases); err != nil {
+			return nil, Validators{}, fmt.Errorf("parsing releases data: %w", err)
 		}
+		releases = append(releases, pageReleases...)
+
+		url = nextPageURL(header)
 	}
 
-	return result, nil
+	// Sort by published date (newest first)
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].PublishedAt.After(releases[j].PublishedAt)
+	})
+
+	return releases, respValidators, nil
 }
@@ -0,0 +1,245 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package github_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"testing"
+	"time"
+)
+
+func setup(t *testing.T, handler http.HandlerFunc, extra ...github.Option) *github.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts := []github.Option{
+		github.WithBaseURL(server.URL),
+		github.WithTransport(server.Client().Transport),
+		github.WithTimeout(5 * time.Second),
+		github.WithUserAgent("p86l-test"),
+	}
+	return github.NewClient(append(opts, extra...)...)
+}
+
+func TestBaseURL(t *testing.T) {
+	client := github.NewClient()
+	if client.BaseURL() != github.DefaultBaseURL {
+		t.Fatalf("expected %s, got %s", github.DefaultBaseURL, client.BaseURL())
+	}
+
+	client = github.NewClient(github.WithBaseURL("https://ghe.example.com/api/v3/"))
+	if client.BaseURL() != "https://ghe.example.com/api/v3" {
+		t.Fatalf("unexpected base url: %s", client.BaseURL())
+	}
+}
+
+func TestGetRateLimit(t *testing.T) {
+	client := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rate_limit" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("User-Agent") != "p86l-test" {
+			t.Errorf("unexpected user agent: %s", r.Header.Get("User-Agent"))
+		}
+		_, _ = w.Write([]byte(`{"resources":{"core":{"limit":60,"remaining":42,"reset":1700000000}}}`))
+	})
+
+	rl, err := client.GetRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if rl.Limit != 60 || rl.Remaining != 42 {
+		t.Fatalf("unexpected rate limit: %+v", rl)
+	}
+}
+
+func TestToken(t *testing.T) {
+	client := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		_, _ = w.Write([]byte(`{"resources":{"core":{"limit":5000,"remaining":4999,"reset":1700000000}}}`))
+	}, github.WithToken(" secret\n"))
+
+	if !client.Authenticated() {
+		t.Fatal("expected authenticated client")
+	}
+
+	rl, err := client.GetRateLimit(context.Background())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if rl.Limit != 5000 {
+		t.Fatalf("unexpected limit: %d", rl.Limit)
+	}
+}
+
+func TestListReleasesNotModified(t *testing.T) {
+	const etag = `"abc"`
+	client := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"tag_name":"v0.1.0"}]`))
+	})
+
+	_, validators, err := client.ListReleases(context.Background(), "owner", "repo", github.Validators{})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if validators.ETag != etag {
+		t.Fatalf("unexpected etag: %s", validators.ETag)
+	}
+
+	releases, _, err := client.ListReleases(context.Background(), "owner", "repo", validators)
+	if !errors.Is(err, log.ErrGithubNotModified) {
+		t.Fatalf("expected not modified, got %v", err)
+	}
+	if releases != nil {
+		t.Fatalf("unexpected releases: %+v", releases)
+	}
+}
+
+func TestListReleasesPagination(t *testing.T) {
+	var serverURL string
+	client := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/releases?page=2>; rel="next", <%s/repos/owner/repo/releases?page=2>; rel="last"`, serverURL, serverURL))
+			_, _ = w.Write([]byte(`[{"tag_name":"v0.2.0","published_at":"2025-02-01T00:00:00Z"}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"tag_name":"v0.1.0","published_at":"2025-01-01T00:00:00Z"}]`))
+		default:
+			t.Errorf("unexpected page: %s", r.URL.RawQuery)
+		}
+	})
+	serverURL = client.BaseURL()
+
+	releases, _, err := client.ListReleases(context.Background(), "owner", "repo", github.Validators{})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(releases) != 2 || releases[0].TagName != "v0.2.0" || releases[1].TagName != "v0.1.0" {
+		t.Fatalf("unexpected releases: %+v", releases)
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	client := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+	})
+
+	_, err := client.GetRateLimit(context.Background())
+	if !errors.Is(err, log.ErrGithubRequestStatus) {
+		t.Fatalf("expected status error, got %v", err)
+	}
+
+	var apiErr *github.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *github.APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden || apiErr.Message != "API rate limit exceeded" {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+	if !apiErr.IsRateLimit() || apiErr.RateLimit.Limit != 60 {
+		t.Fatalf("expected rate limit error: %+v", apiErr)
+	}
+	if apiErr.RetryDelay() != 30*time.Second {
+		t.Fatalf("unexpected retry delay: %s", apiErr.RetryDelay())
+	}
+}
+
+func TestRateLimitFunc(t *testing.T) {
+	var got *github.RateLimitCore
+	client := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		_, _ = w.Write([]byte(`[]`))
+	}, github.WithRateLimitFunc(func(rl *github.RateLimitCore) { got = rl }))
+
+	if _, _, err := client.ListReleases(context.Background(), "owner", "repo", github.Validators{}); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if got == nil || got.Limit != 5000 || got.Remaining != 4321 || got.Reset != 1700000000 {
+		t.Fatalf("unexpected rate limit: %+v", got)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	client := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("0123456789"))
+	}, github.WithToken("secret"))
+
+	data, err := client.Download(context.Background(), client.BaseURL()+"/SHA256SUMS", 4)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if string(data) != "0123" {
+		t.Fatalf("unexpected data: %q", data)
+	}
+
+	if _, err := client.Download(context.Background(), client.BaseURL()+"/missing", 4); !errors.Is(err, log.ErrGithubRequestStatus) {
+		t.Fatalf("expected status error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Download(ctx, client.BaseURL()+"/SHA256SUMS", 4); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+}
+
+func TestDownloadOtherHost(t *testing.T) {
+	assets := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("token sent to another host")
+		}
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer assets.Close()
+
+	client := setup(t, func(w http.ResponseWriter, r *http.Request) {}, github.WithToken("secret"))
+	if _, err := client.Download(context.Background(), assets.URL+"/game.zip.minisig", 4); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
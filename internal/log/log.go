@@ -135,11 +135,53 @@ var (
 	ErrFileRemove = errors.New("failed to remove file")
 	ErrFileLoad   = errors.New("failed to load file")
 	ErrFileSave   = errors.New("failed to save file")
+	ErrFileCopy   = errors.New("failed to copy file")
 
 	ErrGithubRequestNew      = errors.New("failed to create new request")
 	ErrGithubRequestDo       = errors.New("failed to execute request")
 	ErrGithubRequestStatus   = errors.New("github api returned status")
 	ErrGithubRequestBodyRead = errors.New("reading body failed")
+	ErrGithubNotModified     = errors.New("github api returned not modified")
+
+	ErrSourceKind     = errors.New("unknown release source")
+	ErrSourceURL      = errors.New("release source needs an url")
+	ErrSourceManifest = errors.New("invalid release manifest")
+
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	ErrSignatureKey     = errors.New("invalid signing public key")
+	ErrSignatureMissing = errors.New("release asset is not signed")
+	ErrSignatureInvalid = errors.New("signature verification failed")
+
+	ErrAssetPattern   = errors.New("invalid asset pattern")
+	ErrAssetNotFound  = errors.New("no release asset matches this platform")
+	ErrAssetAmbiguous = errors.New("several release assets match this platform")
+
+	ErrDownloadNoRanges = errors.New("server does not support range requests")
+	ErrDownloadSize     = errors.New("download size mismatch")
+	ErrDownloadStatus   = errors.New("download returned status")
+
+	ErrDiskSpace      = errors.New("not enough disk space")
+	ErrDiskSpaceQuery = errors.New("failed to query free disk space")
+
+	ErrArchivePath      = errors.New("archive entry escapes the destination")
+	ErrArchiveEntryType = errors.New("archive entry is a link or special file")
+	ErrArchiveFiles     = errors.New("archive has too many entries")
+	ErrArchiveSize      = errors.New("archive extracts to too many bytes")
+	ErrArchiveRatio     = errors.New("archive entry compression ratio is too high")
+
+	ErrPatchInvalid    = errors.New("invalid binary patch")
+	ErrManifestInvalid = errors.New("invalid build manifest")
+	ErrManifestFile    = errors.New("file does not match the build manifest")
+	ErrManifestMissing = errors.New("build has no manifest")
+
+	ErrChannelInvalid = errors.New("invalid channel")
+
+	ErrBuildInvalid = errors.New("extracted build is missing the game executable")
+	ErrBuildSwap    = errors.New("failed to swap in the new build")
+	ErrBuildBackup  = errors.New("no backup build to roll back to")
+
+	ErrTaskPaused   = errors.New("task paused")
+	ErrTaskCanceled = errors.New("task canceled")
 )
 
 func newLogFile(root *os.Root, path string) (*os.File, *os.File, error) {
@@ -163,6 +205,19 @@ func newLogFile(root *os.Root, path string) (*os.File, *os.File, error) {
 	return main, latest, nil
 }
 
+// HasDebugToken reports whether P86L_DEBUG holds token, only dev builds read it.
+func HasDebugToken(VERSION, token string) bool {
+	if VERSION != "dev" {
+		return false
+	}
+	for t := range strings.SplitSeq(os.Getenv("P86L_DEBUG"), ",") {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
 func NewLogger(VERSION string, fs *os.Root) (*zerolog.Logger, *LogCapture, []*os.File, bool, bool, error) {
 	capture := NewLogCapture(io.Discard)
 
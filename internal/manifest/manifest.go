@@ -0,0 +1,192 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+// Package manifest describes the files of a game build, so updates can fetch only what changed.
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"p86l/configs"
+	"p86l/internal/archive"
+	"p86l/internal/checksum"
+	"p86l/internal/log"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var sha256Hex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Manifest lists every file of a build, published as a release asset next to the game archive:
+//
+//	{
+//	  "tag": "v0.2.0",
+//	  "base_url": "https://example.org/builds/v0.2.0/",
+//	  "files": [
+//	    {"path": "Project-86.exe", "size": 651264, "sha256": "<hex>",
+//	     "patches": [{"from": "<hex>", "path": "patches/Project-86.exe.v0.1.0.bsdiff", "size": 1024, "sha256": "<hex>"}]}
+//	  ]
+//	}
+type Manifest struct {
+	Tag string `json:"tag"`
+	// Files and patches are fetched from it, relative to the manifest url. Empty disables partial updates.
+	BaseURL string `json:"base_url,omitempty"`
+	Files   []File `json:"files"`
+}
+
+type File struct {
+	// Slash separated, relative to the build folder.
+	Path    string  `json:"path"`
+	Size    int64   `json:"size"`
+	SHA256  string  `json:"sha256"`
+	Patches []Patch `json:"patches,omitempty"`
+}
+
+// Patch is a bsdiff from an older version of a file.
+type Patch struct {
+	// sha256 of the file it applies to.
+	From   string `json:"from"`
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// PatchFrom returns the patch that applies to the file with the current sha256, or nil.
+func (f *File) PatchFrom(current string) *Patch {
+	for i := range f.Patches {
+		if f.Patches[i].From == current {
+			return &f.Patches[i]
+		}
+	}
+	return nil
+}
+
+// Parse reads and validates a manifest, paths must stay inside the build folder.
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("%w: %w", log.ErrManifestInvalid, err)
+	}
+
+	seen := make(map[string]bool, len(m.Files))
+	for i := range m.Files {
+		f := &m.Files[i]
+		f.SHA256 = checksum.Normalize(f.SHA256)
+		if !archive.IsLocal(f.Path) || seen[f.Path] || f.Size < 0 || !sha256Hex.MatchString(f.SHA256) {
+			return nil, fmt.Errorf("%w: bad entry %q", log.ErrManifestInvalid, f.Path)
+		}
+		seen[f.Path] = true
+
+		for j := range f.Patches {
+			p := &f.Patches[j]
+			p.From, p.SHA256 = checksum.Normalize(p.From), checksum.Normalize(p.SHA256)
+			if !archive.IsLocal(p.Path) || p.Size < 0 || !sha256Hex.MatchString(p.From) || !sha256Hex.MatchString(p.SHA256) {
+				return nil, fmt.Errorf("%w: bad patch for %q", log.ErrManifestInvalid, f.Path)
+			}
+		}
+	}
+
+	return &m, nil
+}
+
+// Hash lists every file of the build in dir of root, except configs.FileBuildManifest.
+func Hash(root *os.Root, dir, tag string) (*Manifest, error) {
+	m := &Manifest{Tag: tag}
+
+	err := fs.WalkDir(root.FS(), filepath.ToSlash(dir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel := strings.TrimPrefix(path, filepath.ToSlash(dir)+"/")
+		if rel == configs.FileBuildManifest {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		sum, err := hashFile(root, filepath.FromSlash(path))
+		if err != nil {
+			return err
+		}
+		m.Files = append(m.Files, File{Path: rel, Size: info.Size(), SHA256: sum})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *Manifest) Marshal() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// Change is a file of the manifest the build lacks or has another version of.
+type Change struct {
+	File
+	// sha256 of the installed file, empty when it is missing.
+	Current string
+}
+
+// Diff compares the build in dir of root against m, files the manifest does not list are ignored.
+// It stops with the cause of ctx between files.
+func Diff(ctx context.Context, root *os.Root, dir string, m *Manifest) ([]Change, error) {
+	var changes []Change
+
+	for _, f := range m.Files {
+		if ctx.Err() != nil {
+			return nil, context.Cause(ctx)
+		}
+
+		current, err := hashFile(root, filepath.Join(dir, filepath.FromSlash(f.Path)))
+		if errors.Is(err, fs.ErrNotExist) {
+			changes = append(changes, Change{File: f})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if current != f.SHA256 {
+			changes = append(changes, Change{File: f, Current: current})
+		}
+	}
+
+	return changes, nil
+}
+
+func hashFile(root *os.Root, path string) (string, error) {
+	file, err := root.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	return checksum.SHA256(file)
+}
@@ -0,0 +1,154 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package manifest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"p86l/configs"
+	"p86l/internal/log"
+	"p86l/internal/manifest"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	// sha256 of "MZ" and "level".
+	exeDigest   = "9b8db510ef42b8ed54a3712636fda55a4f8cfcd5493e20b74ab00cd4f3979f2d"
+	levelDigest = "0081779c287d567d9ca622f4c0cc2ede819b0cc7f286a5f01d8c3c0178191ad6"
+)
+
+// writeBuild writes files into a temporary build folder and opens it.
+func writeBuild(t *testing.T, files map[string]string) *os.Root {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("%v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	t.Cleanup(func() { _ = root.Close() })
+	return root
+}
+
+func TestParse(t *testing.T) {
+	m, err := manifest.Parse([]byte(`{
+		"tag": "v0.2.0",
+		"base_url": "files/",
+		"files": [
+			{"path": "Project-86.exe", "size": 2, "sha256": "SHA256:` + exeDigest + `",
+			 "patches": [{"from": "` + levelDigest + `", "path": "patches/Project-86.exe.bsdiff", "size": 10, "sha256": "` + exeDigest + `"}]}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if m.Files[0].SHA256 != exeDigest {
+		t.Fatalf("digest not normalized: %s", m.Files[0].SHA256)
+	}
+	if m.Files[0].PatchFrom(levelDigest) == nil || m.Files[0].PatchFrom(exeDigest) != nil {
+		t.Fatalf("unexpected patch lookup")
+	}
+
+	for _, bad := range []string{
+		`{"files": [{"path": "../evil.exe", "size": 2, "sha256": "` + exeDigest + `"}]}`,
+		`{"files": [{"path": "Project-86.exe", "size": 2, "sha256": "nothex"}]}`,
+		`{"files": [{"path": "a", "size": 2, "sha256": "` + exeDigest + `"}, {"path": "a", "size": 2, "sha256": "` + exeDigest + `"}]}`,
+		`not json`,
+	} {
+		if _, err := manifest.Parse([]byte(bad)); !errors.Is(err, log.ErrManifestInvalid) {
+			t.Fatalf("expected %v for %s, got %v", log.ErrManifestInvalid, bad, err)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	root := writeBuild(t, map[string]string{
+		"build/Project-86.exe":               "MZ",
+		"build/Project-86_Data/level":        "level",
+		"build/" + configs.FileBuildManifest: "{}",
+	})
+
+	m, err := manifest.Hash(root, "build", "v0.1.0")
+	if err != nil {
+		t.Fatalf("failed to hash: %v", err)
+	}
+	if m.Tag != "v0.1.0" || len(m.Files) != 2 {
+		t.Fatalf("unexpected manifest: %+v", m)
+	}
+	if f := m.Files[0]; f.Path != "Project-86.exe" || f.Size != 2 || f.SHA256 != exeDigest {
+		t.Fatalf("unexpected file: %+v", f)
+	}
+	if f := m.Files[1]; f.Path != "Project-86_Data/level" || f.SHA256 != levelDigest {
+		t.Fatalf("unexpected file: %+v", f)
+	}
+
+	changes, err := manifest.Diff(context.Background(), root, "build", m)
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("unexpected changes: %+v, %v", changes, err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	root := writeBuild(t, map[string]string{
+		"build/Project-86.exe":        "MZ",
+		"build/Project-86_Data/level": "old level",
+		"build/extra.txt":             "not listed",
+	})
+
+	m := &manifest.Manifest{Files: []manifest.File{
+		{Path: "Project-86.exe", Size: 2, SHA256: exeDigest},
+		{Path: "Project-86_Data/level", Size: 5, SHA256: levelDigest},
+		{Path: "Project-86_Data/missing", Size: 5, SHA256: levelDigest},
+	}}
+
+	changes, err := manifest.Diff(context.Background(), root, "build", m)
+	if err != nil {
+		t.Fatalf("failed to diff: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if changes[0].Path != "Project-86_Data/level" || changes[0].Current == "" {
+		t.Fatalf("unexpected modified file: %+v", changes[0])
+	}
+	if changes[1].Path != "Project-86_Data/missing" || changes[1].Current != "" {
+		t.Fatalf("unexpected missing file: %+v", changes[1])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := manifest.Diff(ctx, root, "build", m); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled, got %v", err)
+	}
+}
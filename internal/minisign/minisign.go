@@ -0,0 +1,145 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package minisign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"p86l/internal/log"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	algLegacy    = "Ed" // signs the file itself
+	algPrehashed = "ED" // signs the BLAKE2b-512 of the file
+)
+
+// MaxUnhashedSize caps the files checked against a legacy or bare signature, they are read
+// into memory whole. Archives are signed prehashed and streamed.
+const MaxUnhashedSize = 16 << 20
+
+type PublicKey struct {
+	KeyID [8]byte
+	Key   ed25519.PublicKey
+}
+
+// ParsePublicKey accepts the content of a minisign .pub file or only its base64 line.
+func ParsePublicKey(text string) (*PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(lastLine(text))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", log.ErrSignatureKey, err)
+	}
+	if len(data) != 2+8+ed25519.PublicKeySize || string(data[:2]) != algLegacy {
+		return nil, log.ErrSignatureKey
+	}
+
+	pub := &PublicKey{Key: ed25519.PublicKey(data[10:])}
+	copy(pub.KeyID[:], data[2:10])
+	return pub, nil
+}
+
+// Verify checks r against the content of a .minisig file, including its trusted comment.
+func (p *PublicKey) Verify(r io.Reader, minisig []byte) error {
+	lines := strings.Split(strings.ReplaceAll(string(minisig), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return fmt.Errorf("%w: malformed signature file", log.ErrSignatureInvalid)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed signature", log.ErrSignatureInvalid)
+	}
+	if !bytes.Equal(sig[2:10], p.KeyID[:]) {
+		return fmt.Errorf("%w: signed with another key", log.ErrSignatureInvalid)
+	}
+
+	var message []byte
+	switch string(sig[:2]) {
+	case algLegacy:
+		message, err = readUnhashed(r)
+	case algPrehashed:
+		h, _ := blake2b.New512(nil)
+		_, err = io.Copy(h, r)
+		message = h.Sum(nil)
+	default:
+		return fmt.Errorf("%w: unknown algorithm", log.ErrSignatureInvalid)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(p.Key, message, sig[10:]) {
+		return log.ErrSignatureInvalid
+	}
+
+	// The global signature covers the file signature and the trusted comment.
+	comment := strings.TrimPrefix(lines[2], "trusted comment: ")
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || !ed25519.Verify(p.Key, append(bytes.Clone(sig[10:]), comment...), globalSig) {
+		return fmt.Errorf("%w: trusted comment", log.ErrSignatureInvalid)
+	}
+
+	return nil
+}
+
+// VerifyRaw checks r against a bare ed25519 signature, either binary or base64.
+func (p *PublicKey) VerifyRaw(r io.Reader, sig []byte) error {
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil || len(decoded) != ed25519.SignatureSize {
+			return fmt.Errorf("%w: malformed signature", log.ErrSignatureInvalid)
+		}
+		sig = decoded
+	}
+
+	message, err := readUnhashed(r)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(p.Key, message, sig) {
+		return log.ErrSignatureInvalid
+	}
+	return nil
+}
+
+// readUnhashed reads r whole, refusing more than MaxUnhashedSize bytes.
+func readUnhashed(r io.Reader) ([]byte, error) {
+	message, err := io.ReadAll(io.LimitReader(r, MaxUnhashedSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(message) > MaxUnhashedSize {
+		return nil, fmt.Errorf("%w: file too large for an unhashed signature, sign it prehashed", log.ErrSignatureInvalid)
+	}
+	return message, nil
+}
+
+func lastLine(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,115 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package minisign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"p86l/internal/log"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+func newKey(t *testing.T) (*PublicKey, ed25519.PrivateKey) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	keyID := []byte("p86ltest")
+	encoded := base64.StdEncoding.EncodeToString(append(append([]byte(algLegacy), keyID...), pub...))
+	key, err := ParsePublicKey("untrusted comment: minisign public key\n" + encoded + "\n")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return key, priv
+}
+
+func sign(key *PublicKey, priv ed25519.PrivateKey, alg string, message []byte) []byte {
+	if alg == algPrehashed {
+		sum := blake2b.Sum512(message)
+		message = sum[:]
+	}
+
+	sig := ed25519.Sign(priv, message)
+	comment := "timestamp:0\tfile:Project86-v0.1.0.zip"
+	globalSig := ed25519.Sign(priv, append(bytes.Clone(sig), comment...))
+
+	return fmt.Appendf(nil, "untrusted comment: signature\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte(alg), key.KeyID[:]...), sig...)),
+		comment,
+		base64.StdEncoding.EncodeToString(globalSig),
+	)
+}
+
+func TestVerify(t *testing.T) {
+	key, priv := newKey(t)
+	message := []byte("game archive")
+
+	for _, alg := range []string{algLegacy, algPrehashed} {
+		minisig := sign(key, priv, alg, message)
+		if err := key.Verify(bytes.NewReader(message), minisig); err != nil {
+			t.Fatalf("%s: %v", alg, err)
+		}
+
+		err := key.Verify(strings.NewReader("tampered archive"), minisig)
+		if !errors.Is(err, log.ErrSignatureInvalid) {
+			t.Fatalf("%s: expected invalid signature, got %v", alg, err)
+		}
+	}
+}
+
+func TestVerifyRaw(t *testing.T) {
+	key, priv := newKey(t)
+	message := []byte("game archive")
+	sig := ed25519.Sign(priv, message)
+
+	if err := key.VerifyRaw(bytes.NewReader(message), sig); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := key.VerifyRaw(bytes.NewReader(message), []byte(base64.StdEncoding.EncodeToString(sig))); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := key.VerifyRaw(strings.NewReader("tampered archive"), sig); !errors.Is(err, log.ErrSignatureInvalid) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+}
+
+func TestVerifyTooLarge(t *testing.T) {
+	key, priv := newKey(t)
+	large := make([]byte, MaxUnhashedSize+1)
+
+	if err := key.Verify(bytes.NewReader(large), sign(key, priv, algLegacy, large)); !errors.Is(err, log.ErrSignatureInvalid) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+	if err := key.Verify(bytes.NewReader(large), sign(key, priv, algPrehashed, large)); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := key.VerifyRaw(bytes.NewReader(large), ed25519.Sign(priv, large)); !errors.Is(err, log.ErrSignatureInvalid) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package source
+
+import (
+	"context"
+	"p86l/internal/github"
+	"strings"
+)
+
+// Gitea works for Gitea and Forgejo, their releases API mirrors GitHub's,
+// including Link pagination, so it goes through github.Client.
+type Gitea struct {
+	client      *github.Client
+	owner, repo string
+}
+
+func NewGitea(config Config, opts ...github.Option) *Gitea {
+	clientOpts := []github.Option{
+		github.WithBaseURL(strings.TrimRight(config.URL, "/") + "/api/v1"),
+		github.WithToken(config.Token),
+	}
+
+	return &Gitea{
+		client: github.NewClient(append(clientOpts, opts...)...),
+		owner:  config.Owner,
+		repo:   config.Repo,
+	}
+}
+
+func (g *Gitea) Client() *github.Client {
+	return g.client
+}
+
+func (g *Gitea) Name() string {
+	return string(KindGitea)
+}
+
+func (g *Gitea) ListReleases(ctx context.Context, validators github.Validators) ([]github.RepositoryRelease, github.Validators, error) {
+	return g.client.ListReleases(ctx, g.owner, g.repo, validators)
+}
+
+// RateLimit returns nil, Gitea has no rate limit endpoint.
+func (g *Gitea) RateLimit(ctx context.Context) (*github.RateLimitCore, error) {
+	return nil, nil
+}
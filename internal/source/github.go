@@ -0,0 +1,61 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package source
+
+import (
+	"context"
+	"p86l/internal/github"
+)
+
+type Github struct {
+	client      *github.Client
+	owner, repo string
+}
+
+func NewGithub(config Config, opts ...github.Option) *Github {
+	clientOpts := []github.Option{
+		github.WithBaseURL(config.URL),
+		github.WithToken(config.Token),
+	}
+
+	return &Github{
+		client: github.NewClient(append(clientOpts, opts...)...),
+		owner:  config.Owner,
+		repo:   config.Repo,
+	}
+}
+
+func (g *Github) Client() *github.Client {
+	return g.client
+}
+
+func (g *Github) Name() string {
+	return string(KindGithub)
+}
+
+func (g *Github) ListReleases(ctx context.Context, validators github.Validators) ([]github.RepositoryRelease, github.Validators, error) {
+	return g.client.ListReleases(ctx, g.owner, g.repo, validators)
+}
+
+func (g *Github) RateLimit(ctx context.Context) (*github.RateLimitCore, error) {
+	return g.client.GetRateLimit(ctx)
+}
@@ -0,0 +1,92 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"sort"
+)
+
+// Manifest reads releases from a static json document, either a list of releases in
+// GitHub's format or an object with that list under "releases".
+type Manifest struct {
+	client *github.Client
+	url    string
+}
+
+// NewManifest never sends the token, the document usually lives on a third party server.
+func NewManifest(config Config, opts ...github.Option) *Manifest {
+	return &Manifest{
+		client: github.NewClient(opts...),
+		url:    config.URL,
+	}
+}
+
+func (m *Manifest) Client() *github.Client {
+	return m.client
+}
+
+func (m *Manifest) Name() string {
+	return string(KindManifest)
+}
+
+func (m *Manifest) ListReleases(ctx context.Context, validators github.Validators) ([]github.RepositoryRelease, github.Validators, error) {
+	data, header, err := m.client.Get(ctx, m.url, validators)
+	respValidators := github.Validators{
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+	}
+	if err != nil {
+		return nil, respValidators, err
+	}
+
+	var releases []github.RepositoryRelease
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &releases)
+	} else {
+		var doc struct {
+			Releases []github.RepositoryRelease `json:"releases"`
+		}
+		err = json.Unmarshal(data, &doc)
+		releases = doc.Releases
+	}
+	if err != nil {
+		return nil, github.Validators{}, fmt.Errorf("%w: %w", log.ErrSourceManifest, err)
+	}
+
+	// Sort by published date (newest first)
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].PublishedAt.After(releases[j].PublishedAt)
+	})
+
+	return releases, respValidators, nil
+}
+
+// RateLimit returns nil, static servers have no rate limit.
+func (m *Manifest) RateLimit(ctx context.Context) (*github.RateLimitCore, error) {
+	return nil, nil
+}
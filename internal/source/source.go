@@ -0,0 +1,88 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"strings"
+)
+
+// ReleaseSource is where the launcher finds game releases.
+type ReleaseSource interface {
+	// Name is used in logs.
+	Name() string
+	// ListReleases returns every release, newest first. Sources that support it return
+	// log.ErrGithubNotModified when validators still match.
+	ListReleases(ctx context.Context, validators github.Validators) ([]github.RepositoryRelease, github.Validators, error)
+	// RateLimit returns nil without error when the source has no rate limit.
+	RateLimit(ctx context.Context) (*github.RateLimitCore, error)
+	// Client fetches release assets, like checksum files, with the token of the source.
+	Client() *github.Client
+}
+
+type Kind string
+
+const (
+	KindGithub   Kind = "github"
+	KindGitea    Kind = "gitea"
+	KindManifest Kind = "manifest"
+)
+
+type Config struct {
+	Kind Kind
+	// API base url for github, instance url for gitea, document url for manifest.
+	URL         string
+	Owner, Repo string
+	Token       string
+}
+
+// New builds the source picked by config, opts are passed to the underlying github.Client.
+func New(config Config, opts ...github.Option) (ReleaseSource, error) {
+	switch config.Kind {
+	case "", KindGithub:
+		return NewGithub(config, opts...), nil
+	case KindGitea:
+		if config.URL == "" {
+			return nil, fmt.Errorf("%w: %s", log.ErrSourceURL, config.Kind)
+		}
+		return NewGitea(config, opts...), nil
+	case KindManifest:
+		if config.URL == "" {
+			return nil, fmt.Errorf("%w: %s", log.ErrSourceURL, config.Kind)
+		}
+		return NewManifest(config, opts...), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", log.ErrSourceKind, config.Kind)
+	}
+}
+
+// ParseRepo splits "owner/repo", ok is false when value is not in that form.
+func ParseRepo(value string) (owner, repo string, ok bool) {
+	owner, repo, ok = strings.Cut(value, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", false
+	}
+	return owner, repo, true
+}
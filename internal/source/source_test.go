@@ -0,0 +1,110 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package source_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"p86l/internal/source"
+	"testing"
+)
+
+func TestNewInvalid(t *testing.T) {
+	if _, err := source.New(source.Config{Kind: "svn"}); !errors.Is(err, log.ErrSourceKind) {
+		t.Fatalf("expected unknown kind, got %v", err)
+	}
+	if _, err := source.New(source.Config{Kind: source.KindManifest}); !errors.Is(err, log.ErrSourceURL) {
+		t.Fatalf("expected missing url, got %v", err)
+	}
+}
+
+func TestGitea(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/owner/repo/releases" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"tag_name":"v0.1.0"}]`))
+	}))
+	defer server.Close()
+
+	src, err := source.New(source.Config{Kind: source.KindGitea, URL: server.URL, Owner: "owner", Repo: "repo"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	releases, _, err := src.ListReleases(context.Background(), github.Validators{})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(releases) != 1 || releases[0].TagName != "v0.1.0" {
+		t.Fatalf("unexpected releases: %+v", releases)
+	}
+
+	rl, err := src.RateLimit(context.Background())
+	if rl != nil || err != nil {
+		t.Fatalf("expected no rate limit, got %+v, %v", rl, err)
+	}
+}
+
+func TestManifest(t *testing.T) {
+	for _, doc := range []string{
+		`[{"tag_name":"v0.1.0","published_at":"2025-01-01T00:00:00Z"},{"tag_name":"v0.2.0","published_at":"2025-02-01T00:00:00Z"}]`,
+		`{"releases":[{"tag_name":"v0.1.0","published_at":"2025-01-01T00:00:00Z"},{"tag_name":"v0.2.0","published_at":"2025-02-01T00:00:00Z"}]}`,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" {
+				t.Error("manifest source must not send the token")
+			}
+			_, _ = w.Write([]byte(doc))
+		}))
+		defer server.Close()
+
+		src, err := source.New(source.Config{Kind: source.KindManifest, URL: server.URL + "/releases.json", Token: "secret"})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		releases, _, err := src.ListReleases(context.Background(), github.Validators{})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if len(releases) != 2 || releases[0].TagName != "v0.2.0" {
+			t.Fatalf("unexpected releases: %+v", releases)
+		}
+	}
+}
+
+func TestParseRepo(t *testing.T) {
+	owner, repo, ok := source.ParseRepo("Taliayaya/Project-86")
+	if !ok || owner != "Taliayaya" || repo != "Project-86" {
+		t.Fatalf("unexpected result: %s, %s, %v", owner, repo, ok)
+	}
+	for _, value := range []string{"", "owner", "/repo", "owner/", "a/b/c"} {
+		if _, _, ok := source.ParseRepo(value); ok {
+			t.Fatalf("expected %q to be invalid", value)
+		}
+	}
+}
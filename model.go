@@ -22,12 +22,20 @@
 package p86l
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"os"
 	"p86l/configs"
+	"p86l/internal/asset"
+	"p86l/internal/channel"
+	"p86l/internal/download"
 	"p86l/internal/file"
 	"p86l/internal/github"
 	"p86l/internal/log"
+	"p86l/internal/source"
 	"path/filepath"
+	"runtime"
 	"sync"
 	"time"
 
@@ -64,21 +72,33 @@ type Model struct {
 
 	isAutoUseDarkmode bool
 	isNew             bool
+	allowUnsigned     bool
 	dataPath          string
 	data              *Data
+	assetMatcher      *asset.Matcher
+	channels          []channel.Channel
+	client            *github.Client
+	downloadLimiter   *download.Limiter
 
 	progressMutex     sync.RWMutex
 	progressRefreshFn func()
 	inProgress        bool
-	progressText      string
+	progress          ProgressEvent
+	progressSubs      []func(ProgressEvent)
 
 	cachePath string
 	cache     *Cache
 
+	taskMutex        sync.Mutex
+	task, pausedTask *task
+
+	fileCheckMutex sync.RWMutex
+	fileCheck      *FileCheck
+
 	commandChan           chan Command
 	cacheResetCommandChan chan struct{}
 
-	isAvailStable, isAvailPreRelease bool
+	isAvail                          map[string]bool
 	fileAvailability                 map[string]bool
 	fileAvailMutex, uiRefreshFnMutex sync.RWMutex
 }
@@ -98,6 +118,25 @@ func NewModel(logger *zerolog.Logger, logCapture *log.LogCapture, fs *file.Files
 		logger.Warn().Str(log.Lifecycle, "could not load cache").Err(err).Msg(log.ErrorManager.String())
 	}
 
+	assetRules := asset.DefaultRules
+	if len(df.AssetRules) > 0 {
+		assetRules = df.AssetRules
+	}
+	assetMatcher, err := asset.New(assetRules)
+	if err != nil {
+		logger.Warn().Str(log.Lifecycle, "invalid asset rules, using defaults").Err(err).Msg(log.ErrorManager.String())
+		assetMatcher, _ = asset.New(asset.DefaultRules)
+	}
+
+	channels := channel.Defaults
+	if len(df.Channels) > 0 {
+		if err := channel.Validate(df.Channels); err != nil {
+			logger.Warn().Str(log.Lifecycle, "invalid channels, using defaults").Err(err).Msg(log.ErrorManager.String())
+		} else {
+			channels = df.Channels
+		}
+	}
+
 	return &Model{
 		ctx:                   ctx,
 		cancel:                cancel,
@@ -110,6 +149,10 @@ func NewModel(logger *zerolog.Logger, logCapture *log.LogCapture, fs *file.Files
 		isNew:                 isNew,
 		dataPath:              dataPath,
 		data:                  NewData(df),
+		assetMatcher:          assetMatcher,
+		channels:              channels,
+		client:                github.NewClient(github.WithUserAgent(configs.AppName)),
+		downloadLimiter:       download.NewLimiter(df.DownloadLimit),
 		cachePath:             cachePath,
 		cache:                 NewCache(cf),
 		commandChan:           make(chan Command, 10),
@@ -148,6 +191,25 @@ func (m *Model) SetIsAutoUseDarkmode(value bool) {
 	m.isAutoUseDarkmode = value
 }
 
+// SetAllowUnsigned skips signature verification, only meant for dev builds.
+func (m *Model) SetAllowUnsigned(value bool) {
+	m.allowUnsigned = value
+}
+
+// SetDownloadLimit caps downloads to bytesPerSecond, 0 is unlimited. Running downloads follow it right away.
+func (m *Model) SetDownloadLimit(bytesPerSecond int64) {
+	m.downloadLimiter.SetLimit(bytesPerSecond)
+	m.data.Update(func(df *DataFile) {
+		df.DownloadLimit = bytesPerSecond
+	})
+	m.logger.Info().Int64("bytes_per_second", bytesPerSecond).Msg(log.NetworkManager.String())
+}
+
+// SetClient replaces the client release assets are fetched with, see CacheSubModel.Client.
+func (m *Model) SetClient(client *github.Client) {
+	m.client = client
+}
+
 func (m *Model) SetProgressRefreshFn(fn func()) {
 	m.progressMutex.Lock()
 	defer m.progressMutex.Unlock()
@@ -250,23 +312,34 @@ func (m *Model) InProgress(value ...bool) bool {
 	return m.inProgress
 }
 
-// Use for single purpose-only, dual will mess with mutex.
-func (m *Model) ProgressText(value ...string) string {
-	if len(value) > 0 {
-		m.progressMutex.Lock()
-		defer m.progressMutex.Unlock()
-		m.progressText = value[0]
+// ProgressText is the text of the last event while its task runs or is paused.
+func (m *Model) ProgressText() string {
+	if !m.TaskRunning() && !m.Paused() {
+		return ""
+	}
+	return m.Progress().String()
+}
 
-		refreshFn := m.progressRefreshFn
-		if refreshFn != nil {
-			refreshFn()
-		}
-	} else {
-		m.progressMutex.RLock()
-		defer m.progressMutex.RUnlock()
+// GameOS is the platform of the builds to install, windows when they run through DataFile.Runner.
+func (m *Model) GameOS() string {
+	if runtime.GOOS != "windows" && len(m.data.Get().Runner) > 0 {
+		return "windows"
+	}
+	return runtime.GOOS
+}
+
+// GameAsset returns the asset of release to install on this platform, see DataFile.AssetRules.
+func (m *Model) GameAsset(release *github.RepositoryRelease) (*github.ReleaseAsset, error) {
+	channel := asset.ChannelStable
+	if release.Prerelease {
+		channel = asset.ChannelPreRelease
 	}
 
-	return m.progressText
+	return m.assetMatcher.Match(release.Assets, asset.Target{
+		GOOS:    m.GameOS(),
+		GOARCH:  runtime.GOARCH,
+		Channel: channel,
+	})
 }
 
 // CheckFilesCached returns cached file exists
@@ -344,28 +417,35 @@ func (d *DataSubModel) updateFilesCache(filePaths ...string) {
 }
 
 func (d *DataSubModel) checkFiles() {
-	filesToCheck := []string{
-		PathGameStable,
-		PathGamePreRelease,
+	goos := d.model.GameOS()
+	var filesToCheck []string
+	for _, c := range d.model.Channels() {
+		filesToCheck = append(filesToCheck, filepath.Join(c.Path(), GameFile(goos)))
+	}
+	pinned := d.model.data.Get().PinnedVersion
+	if pinned != "" {
+		filesToCheck = append(filesToCheck, PathGameVersion(pinned, goos))
 	}
 
 	d.updateFilesCache(filesToCheck...)
 
-	value1 := d.model.CheckFilesCached(PathGameStable)
-	value2 := d.model.CheckFilesCached(PathGamePreRelease)
-
-	if value1 != d.model.isAvailStable || value2 != d.model.isAvailPreRelease {
-		d.model.logger.Info().
-			Str(log.Lifecycle, "game files availability changed").
-			Bool("stable_was", d.model.isAvailStable).
-			Bool("stable_now", value1).
-			Bool("prerelease_was", d.model.isAvailPreRelease).
-			Bool("prerelease_now", value2).
-			Msg(log.AppManager.String())
-
-		d.model.isAvailStable = value1
-		d.model.isAvailPreRelease = value2
+	changed := false
+	isAvail := make(map[string]bool, len(filesToCheck))
+	for _, path := range filesToCheck {
+		isAvail[path] = d.model.CheckFilesCached(path)
+		if isAvail[path] != d.model.isAvail[path] {
+			changed = true
+			d.model.logger.Info().
+				Str(log.Lifecycle, "game files availability changed").
+				Str("path", filepath.ToSlash(path)).
+				Bool("was", d.model.isAvail[path]).
+				Bool("now", isAvail[path]).
+				Msg(log.AppManager.String())
+		}
+	}
 
+	if changed {
+		d.model.isAvail = isAvail
 		d.model.handleUIRefresh()
 	}
 }
@@ -378,25 +458,103 @@ const (
 	minRefreshInterval       = time.Second * 5
 	rateLimitRefreshInterval = 5 * time.Minute
 	releasesRefreshInterval  = 30 * time.Minute
+	// - Backoff after failed requests
+	minBackoffInterval = 10 * time.Second
+	maxBackoffInterval = time.Hour
 )
 
 type CacheSubModel struct {
 	logger zerolog.Logger
 	model  *Model
-	client *github.Client
+	source source.ReleaseSource
+
+	// Only touched from the background loop.
+	failures    int
+	retryAt     time.Time
+	noRateLimit bool
 }
 
-func NewCacheSubModel(model *Model) *CacheSubModel {
+// NewCacheSubModel creates the cache sub model, extra options are applied after the ones from DataFile.
+func NewCacheSubModel(model *Model, opts ...github.Option) *CacheSubModel {
+	logger := model.logger.With().Str(log.UnknownModel.String(), log.CacheModel.String()).Logger()
+
+	dataFile := model.data.Get()
+	config := source.Config{
+		Kind:  source.Kind(dataFile.ReleaseSource),
+		URL:   dataFile.ReleaseSourceURL,
+		Owner: configs.RepoOwner,
+		Repo:  configs.RepoName,
+	}
+	if config.Kind == "" || config.Kind == source.KindGithub {
+		config.URL = cmp.Or(config.URL, dataFile.GithubAPIURL)
+	}
+	if dataFile.ReleaseSourceRepo != "" {
+		if owner, repo, ok := source.ParseRepo(dataFile.ReleaseSourceRepo); ok {
+			config.Owner, config.Repo = owner, repo
+		} else {
+			logger.Warn().Str(log.Lifecycle, "invalid release source repo, using default").Str("repo", dataFile.ReleaseSourceRepo).Msg(log.ErrorManager.String())
+		}
+	}
+
+	clientOpts := []github.Option{
+		github.WithUserAgent(configs.AppName),
+		github.WithRateLimitFunc(func(rl *github.RateLimitCore) {
+			model.cache.SetRateLimit(rl)
+			model.handleUIRefresh()
+		}),
+	}
+	clientOpts = append(clientOpts, opts...)
+
+	config.Token = sourceToken(config.Kind, dataFile)
+	src, err := source.New(config, clientOpts...)
+	if err != nil {
+		logger.Warn().Str(log.Lifecycle, "invalid release source, using github").Err(err).Msg(log.ErrorManager.String())
+		config.Kind, config.URL = source.KindGithub, dataFile.GithubAPIURL
+		config.Token = sourceToken(config.Kind, dataFile)
+		src = source.NewGithub(config, clientOpts...)
+	}
+
+	logger.Info().
+		Str("source", src.Name()).
+		Str("repo", config.Owner+"/"+config.Repo).
+		Bool("authenticated", config.Token != "").
+		Msg(log.NetworkManager.String())
+
 	return &CacheSubModel{
-		logger: model.logger.With().Str(log.UnknownModel.String(), log.CacheModel.String()).Logger(),
+		logger: logger,
 		model:  model,
-		client: github.NewClient(),
+		source: src,
 	}
 }
 
+// sourceToken returns the token for a source of kind, the GitHub one is never sent to another host.
+func sourceToken(kind source.Kind, dataFile DataFile) string {
+	switch kind {
+	case "", source.KindGithub:
+		return cmp.Or(os.Getenv(configs.EnvGithubToken), dataFile.GithubToken)
+	case source.KindGitea:
+		return cmp.Or(os.Getenv(configs.EnvGiteaToken), dataFile.GiteaToken)
+	default:
+		return ""
+	}
+}
+
+// Client fetches the checksums, signatures and manifests of releases like the source listing them,
+// see Model.SetClient.
+func (c *CacheSubModel) Client() *github.Client {
+	return c.source.Client()
+}
+
 func (c *CacheSubModel) getRefreshInterval() time.Duration {
+	if c.inBackoff() {
+		return time.Until(c.retryAt)
+	}
+
 	cacheFile := c.model.cache.Get()
 	if cacheFile.RateLimit == nil {
+		if c.noRateLimit {
+			return releasesRefreshInterval
+		}
 		return minRefreshInterval
 	}
 	resetTime := cacheFile.RateLimit.Reset
@@ -412,6 +570,36 @@ func (c *CacheSubModel) getRefreshInterval() time.Duration {
 	return interval
 }
 
+func (c *CacheSubModel) inBackoff() bool {
+	return time.Now().Before(c.retryAt)
+}
+
+// backoff delays the next request exponentially, or for as long as GitHub asked with Retry-After.
+func (c *CacheSubModel) backoff(err error) {
+	c.failures++
+	delay := min(minBackoffInterval<<min(c.failures-1, 16), maxBackoffInterval)
+
+	var apiErr *github.APIError
+	if errors.As(err, &apiErr) {
+		delay = max(delay, apiErr.RetryDelay())
+		c.logger.Warn().
+			Int("status", apiErr.StatusCode).
+			Str("message", apiErr.Message).
+			Bool("rate_limit", apiErr.IsRateLimit()).
+			Bool("secondary_rate_limit", apiErr.IsSecondaryRateLimit()).
+			Dur("retry_after", apiErr.RetryAfter).
+			Msg(log.NetworkManager.String())
+	}
+
+	c.retryAt = time.Now().Add(delay)
+	c.logger.Info().Str(log.Lifecycle, "backing off").Int("failures", c.failures).Dur("delay", delay).Msg(log.NetworkManager.String())
+}
+
+func (c *CacheSubModel) resetBackoff() {
+	c.failures = 0
+	c.retryAt = time.Time{}
+}
+
 func (c *CacheSubModel) Start(ctx context.Context, wg *sync.WaitGroup) {
 	c.logger.Info().Str(log.Lifecycle, log.Starting).Msg(log.AppManager.String())
 
@@ -426,7 +614,7 @@ func (c *CacheSubModel) Start(ctx context.Context, wg *sync.WaitGroup) {
 		refT := c.getRefreshInterval()
 		c.logger.Info().Str("refresh ticker", refT.String()).Msg(log.AppManager.String())
 
-		rateLimitTicker := time.NewTimer(rateLimitRefreshInterval)
+		rateLimitTicker := time.NewTicker(rateLimitRefreshInterval)
 		releasesTicker := time.NewTicker(releasesRefreshInterval)
 		refreshTicker := time.NewTicker(refT)
 
@@ -440,21 +628,27 @@ func (c *CacheSubModel) Start(ctx context.Context, wg *sync.WaitGroup) {
 				c.logger.Info().Str(log.BackgroundLoop, log.Stopped).Msg(log.AppManager.String())
 				return
 			case <-rateLimitTicker.C:
+				// Responses keep the rate limit fresh, only poll when nothing was requested for a while.
+				if !c.isRateLimitStale() {
+					continue
+				}
 				c.logger.Info().Str(log.Lifecycle, "time to refresh ratelimit cache").Msg(log.NetworkManager.String())
 				c.fetchRateLimit(ctx)
+				refreshTicker.Reset(c.getRefreshInterval())
 			case <-releasesTicker.C:
 				c.logger.Info().Str(log.Lifecycle, "time to refresh releases cache").Msg(log.NetworkManager.String())
 				c.fetchReleases(ctx)
-				c.fetchRateLimit(ctx)
+				refreshTicker.Reset(c.getRefreshInterval())
 			case <-refreshTicker.C:
 				c.logger.Info().Str(log.Lifecycle, "time to refresh cache").Msg(log.NetworkManager.String())
 				c.fetchReleases(ctx)
-				c.fetchRateLimit(ctx)
 				refreshTicker.Reset(c.getRefreshInterval())
 			case <-c.model.cacheResetCommandChan:
 				c.logger.Info().Str(log.Lifecycle, "forced refresh of cache").Msg(log.NetworkManager.String())
 				c.fetchReleases(ctx)
-				c.fetchRateLimit(ctx)
+				if c.isRateLimitStale() {
+					c.fetchRateLimit(ctx)
+				}
 				refreshTicker.Reset(c.getRefreshInterval())
 			}
 		}
@@ -466,7 +660,7 @@ func (c *CacheSubModel) initialFetch(ctx context.Context) {
 	cacheFile := cache.Get()
 
 	hasRateLimit := cacheFile.RateLimit != nil
-	hasReleases := cacheFile.Releases != nil
+	hasReleases := cacheFile.AllReleases != nil
 
 	rateLimitAge := cache.RateLimitAge()
 	releasesAge := cache.ReleasesAge()
@@ -479,32 +673,53 @@ func (c *CacheSubModel) initialFetch(ctx context.Context) {
 		Dur("rate_limit_age", rateLimitAge).
 		Msg(log.AppManager.String())
 
-	// Always fetch rate limit on start (no rate limit on this endpoint)
-	if !hasRateLimit || rateLimitAge > rateLimitRefreshInterval {
-		c.logger.Info().Str(log.InitialFetch, "fetching initial rate limit").Msg(log.AppManager.String())
-		c.fetchRateLimit(ctx)
-	}
-
 	// Only fetch releases if we don't have them or they're very old
 	if !hasReleases || releasesAge > releasesRefreshInterval {
 		c.logger.Info().Str(log.InitialFetch, "fetching initial releases").Msg(log.AppManager.String())
 		c.fetchReleases(ctx)
-		c.fetchRateLimit(ctx)
 	} else {
 		c.logger.Info().
 			Str(log.InitialFetch, "using cached releases").
 			Dur("age", releasesAge).
 			Msg(log.AppManager.String())
 	}
+
+	// Fallback when the releases response did not carry the rate limit (no rate limit on this endpoint)
+	if c.isRateLimitStale() {
+		c.logger.Info().Str(log.InitialFetch, "fetching initial rate limit").Msg(log.AppManager.String())
+		c.fetchRateLimit(ctx)
+	}
+}
+
+// isRateLimitStale reports whether no response updated the rate limit recently.
+func (c *CacheSubModel) isRateLimitStale() bool {
+	if c.noRateLimit {
+		return false
+	}
+	cache := c.model.cache
+	return cache.Get().RateLimit == nil || cache.RateLimitAge() > rateLimitRefreshInterval
 }
 
 func (c *CacheSubModel) fetchRateLimit(ctx context.Context) {
+	if c.inBackoff() {
+		c.logger.Info().Str(log.FetchRateLimit, "backing off, skipping fetch").Time("retry_at", c.retryAt).Msg(log.AppManager.String())
+		return
+	}
+
 	c.logger.Info().Str(log.FetchRateLimit, "fetching rate limit").Msg(log.AppManager.String())
 	cache := c.model.Cache()
 
-	rl, err := c.client.GetRateLimit(ctx)
+	rl, err := c.source.RateLimit(ctx)
 	if err != nil {
 		c.logger.Warn().Str(log.FetchRateLimit, "failed to fetch rate limit").Err(err).Msg(log.ErrorManager.String())
+		c.backoff(err)
+		return
+	}
+	c.resetBackoff()
+
+	if rl == nil {
+		c.logger.Info().Str(log.FetchRateLimit, "source has no rate limit").Str("source", c.source.Name()).Msg(log.AppManager.String())
+		c.noRateLimit = true
 		return
 	}
 
@@ -519,10 +734,15 @@ func (c *CacheSubModel) fetchRateLimit(ctx context.Context) {
 }
 
 func (c *CacheSubModel) fetchReleases(ctx context.Context) {
+	if c.inBackoff() {
+		c.logger.Info().Str(log.FetchReleases, "backing off, skipping fetch").Time("retry_at", c.retryAt).Msg(log.AppManager.String())
+		return
+	}
+
 	cache := c.model.Cache()
 
 	ratelimit := cache.Get().RateLimit
-	if ratelimit != nil && ratelimit.Remaining < 5 {
+	if ratelimit != nil && ratelimit.Remaining < 5 && time.Now().Before(time.Unix(ratelimit.Reset, 0)) {
 		c.logger.Warn().
 			Str(log.FetchReleases, "rate limit low, skipping fetch").
 			Int("remaining", ratelimit.Remaining).
@@ -532,19 +752,31 @@ func (c *CacheSubModel) fetchReleases(ctx context.Context) {
 
 	c.logger.Info().Str(log.FetchReleases, "fetching releases").Msg(log.AppManager.String())
 
-	lr, err := c.client.GetLatestReleases(ctx, configs.RepoOwner, configs.RepoName)
+	releases, validators, err := c.source.ListReleases(ctx, cache.ReleasesValidators())
+	if errors.Is(err, log.ErrGithubNotModified) {
+		c.logger.Info().
+			Str(log.FetchReleases, "releases not modified").
+			Msg(log.AppManager.String())
+
+		c.resetBackoff()
+		cache.TouchReleases()
+		return
+	}
 	if err != nil {
 		c.logger.Warn().
 			Str(log.FetchReleases, "failed to fetch releases").
 			Err(err).
 			Msg(log.ErrorManager.String())
+		c.backoff(err)
 		return
 	}
+	c.resetBackoff()
 
 	c.logger.Info().
 		Str(log.FetchReleases, "releases updated").
+		Int("count", len(releases)).
 		Msg(log.AppManager.String())
 
-	cache.SetReleases(lr)
+	cache.SetReleases(releases, validators)
 	c.model.handleUIRefresh()
 }
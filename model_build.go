@@ -0,0 +1,217 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"p86l/configs"
+	"p86l/internal/channel"
+	"p86l/internal/log"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// A launch counts as successful once the game exited cleanly or ran this long, the backup is dropped then.
+const backupLaunchTime = time.Minute
+
+// buildPath returns the build folder Play launches.
+func (m *Model) buildPath() string {
+	if pinned := m.Data().Get().PinnedVersion; pinned != "" {
+		return PathBuildVersion(pinned)
+	}
+	return m.Channel().Path()
+}
+
+// hasGame reports whether gamePath holds a build with the game executable.
+func (m *Model) hasGame(gamePath string) bool {
+	return m.fs.Exist(filepath.Join(gamePath, GameFile(m.GameOS())))
+}
+
+// installedTag returns the release installed in gamePath, from its channel state or the manifest saved with it.
+func (m *Model) installedTag(gamePath string) string {
+	if c, ok := m.channelOf(gamePath); ok {
+		return m.Data().Get().ChannelState(c.Name).Installed
+	}
+	if man, err := m.loadManifest(gamePath); err == nil {
+		return man.Tag
+	}
+	return ""
+}
+
+// installBuild extracts zipPath into a staging folder next to gamePath and swaps it in once it holds the game.
+// The build it replaces is kept as a backup of previousTag. On error gamePath is left untouched.
+func (m *Model) installBuild(ctx context.Context, zipPath, gamePath, tag, previousTag string) error {
+	stagingPath := gamePath + configs.SuffixStaging
+
+	// Removes leftovers of a previous attempt.
+	if err := m.fs.Root().RemoveAll(stagingPath); err != nil {
+		return err
+	}
+
+	if err := m.unzipGame(ctx, filepath.Join(m.fs.Path(), zipPath), stagingPath); err != nil {
+		m.removeBuild(stagingPath)
+		return err
+	}
+	if !m.hasGame(stagingPath) {
+		m.removeBuild(stagingPath)
+		return fmt.Errorf("%w: %s", log.ErrBuildInvalid, GameFile(m.GameOS()))
+	}
+	m.hashBuild(stagingPath, tag)
+
+	return m.swapBuild(stagingPath, gamePath, previousTag)
+}
+
+// swapBuild renames stagingPath to gamePath, moving a working build there to its backup folder first.
+func (m *Model) swapBuild(stagingPath, gamePath, previousTag string) error {
+	root := m.fs.Root()
+	backupPath := gamePath + configs.SuffixBackup
+	keepBackup := m.hasGame(gamePath)
+
+	if keepBackup {
+		if err := root.RemoveAll(backupPath); err != nil {
+			return fmt.Errorf("%w: %w", log.ErrBuildSwap, err)
+		}
+		if err := root.Rename(gamePath, backupPath); err != nil {
+			return fmt.Errorf("%w: %w", log.ErrBuildSwap, err)
+		}
+	} else if err := root.RemoveAll(gamePath); err != nil {
+		// Empty or broken, nothing worth keeping.
+		return fmt.Errorf("%w: %w", log.ErrBuildSwap, err)
+	}
+
+	if err := root.Rename(stagingPath, gamePath); err != nil {
+		err = fmt.Errorf("%w: %w", log.ErrBuildSwap, err)
+		if keepBackup {
+			if rErr := root.Rename(backupPath, gamePath); rErr != nil {
+				err = errors.Join(err, rErr)
+			}
+		}
+		return err
+	}
+
+	key := filepath.ToSlash(gamePath)
+	m.Data().Update(func(df *DataFile) {
+		df.BuildBackups = maps.Clone(df.BuildBackups)
+		if keepBackup {
+			if df.BuildBackups == nil {
+				df.BuildBackups = make(map[string]string)
+			}
+			df.BuildBackups[key] = previousTag
+		} else {
+			delete(df.BuildBackups, key)
+		}
+	})
+	m.setFileCheck(nil)
+	if keepBackup {
+		m.logger.Info().Str(log.Lifecycle, "kept previous build").Str("path", filepath.ToSlash(backupPath)).Str("tag", previousTag).Msg(log.FileManager.String())
+	}
+	return nil
+}
+
+func (m *Model) removeBuild(path string) {
+	if err := m.fs.Root().RemoveAll(path); err != nil {
+		m.logger.Warn().Str(log.Lifecycle, "failed to remove partial build").Str("path", filepath.ToSlash(path)).Err(err).Msg(log.ErrorManager.String())
+	}
+}
+
+// HasBackup reports whether the build Play launches can be rolled back.
+func (m *Model) HasBackup() bool {
+	gamePath := m.buildPath()
+	_, ok := m.Data().Get().BuildBackups[filepath.ToSlash(gamePath)]
+	return ok && m.hasGame(gamePath+configs.SuffixBackup)
+}
+
+// dropBackup removes the backup of gamePath, once its new build launched successfully.
+func (m *Model) dropBackup(gamePath string) {
+	key := filepath.ToSlash(gamePath)
+	if _, ok := m.Data().Get().BuildBackups[key]; !ok {
+		return
+	}
+
+	if err := m.fs.Root().RemoveAll(gamePath + configs.SuffixBackup); err != nil {
+		m.logger.Warn().Str(log.Lifecycle, "failed to remove backup build").Str("path", key).Err(err).Msg(log.ErrorManager.String())
+		return
+	}
+	m.Data().Update(func(df *DataFile) {
+		df.BuildBackups = maps.Clone(df.BuildBackups)
+		delete(df.BuildBackups, key)
+	})
+	m.logger.Info().Str(log.Lifecycle, "removed backup build").Str("path", key).Msg(log.FileManager.String())
+}
+
+// Rollback puts the backup of the build Play launches back in place of the current one.
+func (m *Model) Rollback() {
+	m.runTask(func(ctx context.Context) {
+		if err := m.rollback(m.buildPath()); err != nil {
+			mErr := T("model_play.fail_rollback")
+			m.failProgress(mErr, err)
+			m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
+			return
+		}
+		m.publishProgress(ProgressEvent{Phase: PhaseDone, Message: T("model_play.rollback_finished")})
+	})
+}
+
+func (m *Model) rollback(gamePath string) error {
+	root := m.fs.Root()
+	key := filepath.ToSlash(gamePath)
+	backupPath := gamePath + configs.SuffixBackup
+	stagingPath := gamePath + configs.SuffixStaging
+
+	tag, ok := m.Data().Get().BuildBackups[key]
+	if !ok || !m.hasGame(backupPath) {
+		return log.ErrBuildBackup
+	}
+
+	// The current build is moved aside first, so a failed rename can be undone.
+	if err := root.RemoveAll(stagingPath); err != nil {
+		return fmt.Errorf("%w: %w", log.ErrBuildSwap, err)
+	}
+	if err := root.Rename(gamePath, stagingPath); err != nil {
+		return fmt.Errorf("%w: %w", log.ErrBuildSwap, err)
+	}
+	if err := root.Rename(backupPath, gamePath); err != nil {
+		err = fmt.Errorf("%w: %w", log.ErrBuildSwap, err)
+		if rErr := root.Rename(stagingPath, gamePath); rErr != nil {
+			err = errors.Join(err, rErr)
+		}
+		return err
+	}
+	m.removeBuild(stagingPath)
+
+	c, isChannel := m.channelOf(gamePath)
+	m.Data().Update(func(df *DataFile) {
+		df.BuildBackups = maps.Clone(df.BuildBackups)
+		delete(df.BuildBackups, key)
+
+		if isChannel {
+			df.UpdateChannelState(c.Name, func(s *channel.State) { s.Installed = tag })
+		}
+	})
+	m.setFileCheck(nil)
+	m.logger.Info().Str(log.Lifecycle, "rolled back build").Str("path", key).Str("tag", tag).Msg(log.FileManager.String())
+	return nil
+}
@@ -34,11 +34,15 @@ import (
 )
 
 type CacheFile struct {
-	Releases     *github.LatestReleases `json:"releases"`
-	RateLimit    *github.RateLimitCore  `json:"rate_limit"`
-	LastUpdated  time.Time              `json:"last_updated"`
-	ReleasesAge  time.Time              `json:"releases_age"`   // When releases were last fetched
-	RateLimitAge time.Time              `json:"rate_limit_age"` // When rate limit was last fetched
+	AllReleases  []github.RepositoryRelease `json:"all_releases"` // Every release, newest first
+	RateLimit    *github.RateLimitCore      `json:"rate_limit"`
+	LastUpdated  time.Time                  `json:"last_updated"`
+	ReleasesAge  time.Time                  `json:"releases_age"`   // When releases were last fetched
+	RateLimitAge time.Time                  `json:"rate_limit_age"` // When rate limit was last fetched
+
+	// Validators of the last releases response, for conditional requests.
+	ReleasesETag         string `json:"releases_etag"`
+	ReleasesLastModified string `json:"releases_last_modified"`
 
 	ChangelogTranslation string `json:"-"`
 }
@@ -62,14 +66,38 @@ func (c *Cache) SetRateLimit(rl *github.RateLimitCore) {
 	c.file.LastUpdated = time.Now()
 }
 
-func (c *Cache) SetReleases(lr *github.LatestReleases) {
+func (c *Cache) SetReleases(releases []github.RepositoryRelease, validators github.Validators) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.file.AllReleases = releases
+	c.file.ReleasesETag = validators.ETag
+	c.file.ReleasesLastModified = validators.LastModified
+	c.file.ReleasesAge = time.Now()
+	c.file.LastUpdated = time.Now()
+}
+
+// TouchReleases marks the cached releases as fresh, used when the server answered 304 Not Modified.
+func (c *Cache) TouchReleases() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.file.Releases = lr
 	c.file.ReleasesAge = time.Now()
 	c.file.LastUpdated = time.Now()
 }
 
+// ReleasesValidators returns the validators to send with the next releases request,
+// empty when there are no releases to fall back on.
+func (c *Cache) ReleasesValidators() github.Validators {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.file.AllReleases == nil {
+		return github.Validators{}
+	}
+	return github.Validators{
+		ETag:         c.file.ReleasesETag,
+		LastModified: c.file.ReleasesLastModified,
+	}
+}
+
 // GetReleasesAge returns how old the releases data is
 func (c *Cache) ReleasesAge() time.Duration {
 	c.mu.RLock()
@@ -0,0 +1,73 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"p86l/internal/channel"
+	"p86l/internal/github"
+	"p86l/internal/log"
+)
+
+// Channels returns the channels to pick from, DataFile.Channels when they are valid.
+func (m *Model) Channels() []channel.Channel {
+	return m.channels
+}
+
+// Channel returns the channel Play follows, the first one when DataFile.Channel names none.
+func (m *Model) Channel() channel.Channel {
+	if c, ok := channel.Find(m.channels, m.Data().Get().Channel); ok {
+		return c
+	}
+	return m.channels[0]
+}
+
+// SetChannel selects the channel Play follows.
+func (m *Model) SetChannel(name string) {
+	if _, ok := channel.Find(m.channels, name); !ok {
+		return
+	}
+
+	m.Data().Update(func(df *DataFile) {
+		df.Channel = name
+	})
+	m.logger.Info().Str(log.Lifecycle, "channel changed").Str("channel", name).Msg(log.AppManager.String())
+
+	if latest := m.LatestRelease(); latest != nil {
+		m.Translate(ReleasesChangelogText(latest), m.Data().Get().Lang)
+	}
+	m.handleUIRefresh()
+}
+
+// LatestRelease returns the newest release of the channel Play follows, or nil.
+func (m *Model) LatestRelease() *github.RepositoryRelease {
+	return m.Channel().Latest(m.Cache().Get().AllReleases)
+}
+
+// channelOf returns the channel installed in gamePath.
+func (m *Model) channelOf(gamePath string) (channel.Channel, bool) {
+	for _, c := range m.channels {
+		if c.Path() == gamePath {
+			return c, true
+		}
+	}
+	return channel.Channel{}, false
+}
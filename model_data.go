@@ -23,6 +23,10 @@ package p86l
 
 import (
 	"encoding/json"
+	"maps"
+	"p86l/internal/archive"
+	"p86l/internal/asset"
+	"p86l/internal/channel"
 	"p86l/internal/file"
 	"p86l/internal/log"
 	"sync"
@@ -56,16 +60,97 @@ type DataFile struct {
 	UseDarkmode        bool         `json:"use_darkmode"`
 	AppScale           float64      `json:"app_scale"`
 	DisableBgMusic     bool         `json:"disable_bgm"`
-	UsePreRelease      bool         `json:"use_pre_release"`
 	Remember           DataRemember `json:"remember"`
-	// Download in progress/partial content.
-	GameVersion       string `json:"game_version"`
-	PreReleaseVersion string `json:"pre_release_version"`
-	// Currently installed.
-	InstalledGame       string        `json:"installed_game_version"`
-	InstalledPreRelease string        `json:"installed_pre_release_version"`
-	TotalPlayTime       time.Duration `json:"total_play_time"`
-	LastPlayed          time.Time     `json:"last_played"`
+	// Channel Play follows, empty is the first one.
+	Channel string `json:"channel"`
+	// Empty uses channel.Defaults.
+	Channels []channel.Channel `json:"channels"`
+	// Installed and pending versions, keyed by channel name.
+	ChannelStates map[string]channel.State `json:"channel_states"`
+	TotalPlayTime time.Duration            `json:"total_play_time"`
+	LastPlayed    time.Time                `json:"last_played"`
+	// Pinned version in builds/versions, empty follows stable/pre-release.
+	PinnedVersion string `json:"pinned_version"`
+	// Tag of the build kept in <build>.backup by an update, keyed by build folder, until the new one launched once.
+	BuildBackups map[string]string `json:"build_backups"`
+	// Empty uses github.DefaultBaseURL, set for GitHub Enterprise or self-hosted mirrors.
+	GithubAPIURL string `json:"github_api_url"`
+	// One of source.Kind, empty is github. The url and "owner/repo" depend on it, see source.Config.
+	ReleaseSource     string `json:"release_source"`
+	ReleaseSourceURL  string `json:"release_source_url"`
+	ReleaseSourceRepo string `json:"release_source_repo"`
+	// Ranged requests per download, 0 uses download.DefaultConnections and 1 a single stream.
+	DownloadConnections int `json:"download_connections"`
+	// Bytes per second shared by every download, 0 is unlimited.
+	DownloadLimit int64 `json:"download_limit"`
+	// Tried in order when the release url fails, see AssetURLs.
+	DownloadMirrors []string `json:"download_mirrors"`
+	// Command prefix running Windows builds on other platforms, e.g. ["wine"] or ["proton", "run"].
+	Runner []string `json:"runner"`
+	// Empty uses a WINEPREFIX per build in configs.FolderPrefixes.
+	RunnerPrefix string            `json:"runner_prefix"`
+	RunnerEnv    map[string]string `json:"runner_env"`
+	// Picks the game asset of a release, empty uses asset.DefaultRules.
+	AssetRules []asset.Rule `json:"asset_rules"`
+	// Caps on what a game archive may extract to, zero fields use the archive defaults.
+	ArchiveLimits archive.Limits `json:"archive_limits"`
+	// Personal access token, configs.EnvGithubToken takes priority. Never log it, see redacted.
+	GithubToken string `json:"github_token"`
+	// Token of a gitea release source, configs.EnvGiteaToken takes priority. Never log it either.
+	GiteaToken string `json:"gitea_token"`
+}
+
+// ChannelState returns the versions of the channel called name.
+func (d DataFile) ChannelState(name string) channel.State {
+	return d.ChannelStates[name]
+}
+
+// UpdateChannelState changes the versions of the channel called name, call it from Data.Update.
+func (d *DataFile) UpdateChannelState(name string, fn func(*channel.State)) {
+	state := d.ChannelStates[name]
+	fn(&state)
+
+	d.ChannelStates = maps.Clone(d.ChannelStates)
+	if d.ChannelStates == nil {
+		d.ChannelStates = make(map[string]channel.State)
+	}
+	d.ChannelStates[name] = state
+}
+
+// legacyDataFile holds the stable/pre-release fields channels replaced, read to migrate older data files.
+type legacyDataFile struct {
+	UsePreRelease       bool   `json:"use_pre_release"`
+	GameVersion         string `json:"game_version"`
+	PreReleaseVersion   string `json:"pre_release_version"`
+	InstalledGame       string `json:"installed_game_version"`
+	InstalledPreRelease string `json:"installed_pre_release_version"`
+}
+
+func (d *DataFile) migrate(legacy legacyDataFile) {
+	if d.ChannelStates != nil || d.Channel != "" {
+		return
+	}
+
+	if legacy.UsePreRelease {
+		d.Channel = asset.ChannelPreRelease
+	}
+	d.UpdateChannelState(asset.ChannelStable, func(s *channel.State) {
+		s.Installed, s.Pending = legacy.InstalledGame, legacy.GameVersion
+	})
+	d.UpdateChannelState(asset.ChannelPreRelease, func(s *channel.State) {
+		s.Installed, s.Pending = legacy.InstalledPreRelease, legacy.PreReleaseVersion
+	})
+}
+
+// redacted returns a copy that is safe to write into logs.
+func (d DataFile) redacted() DataFile {
+	if d.GithubToken != "" {
+		d.GithubToken = "[redacted]"
+	}
+	if d.GiteaToken != "" {
+		d.GiteaToken = "[redacted]"
+	}
+	return d
 }
 
 type Data struct {
@@ -112,7 +197,6 @@ func loadData(logger *zerolog.Logger, fs *file.Filesystem, dataPath string) (boo
 			UseDarkmode:    false,
 			AppScale:       1,
 			DisableBgMusic: false,
-			UsePreRelease:  false,
 		}
 
 		return true, df, nil
@@ -130,7 +214,12 @@ func loadData(logger *zerolog.Logger, fs *file.Filesystem, dataPath string) (boo
 		return false, nil, err
 	}
 
-	logger.Info().Str(log.Lifecycle, "data loaded successfully").Any("data", df).Msg(log.FileManager.String())
+	// Fields of older versions, the data file above parsed so this cannot fail.
+	var legacy legacyDataFile
+	_ = json.Unmarshal(jsonData, &legacy)
+	df.migrate(legacy)
+
+	logger.Info().Str(log.Lifecycle, "data loaded successfully").Any("data", df.redacted()).Msg(log.FileManager.String())
 	return false, &df, nil
 }
 
@@ -173,7 +262,7 @@ func (r ResetDataCommand) Execute(m *Model) {
 		df.AppScale = 1
 		df.Remember.Active = false
 		df.DisableBgMusic = false
-		df.UsePreRelease = false
+		df.Channel = ""
 	})
 
 	if err := m.syncDataFn(m, true); err != nil {
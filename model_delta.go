@@ -0,0 +1,291 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"p86l/configs"
+	"p86l/internal/bsdiff"
+	"p86l/internal/checksum"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"p86l/internal/manifest"
+	"path/filepath"
+
+	"github.com/cavaliergopher/grab/v3"
+)
+
+// manifestAsset returns the manifest published next to asset, or nil.
+func manifestAsset(release *github.RepositoryRelease, asset *github.ReleaseAsset) *github.ReleaseAsset {
+	for _, name := range []string{asset.Name + ".manifest.json", "manifest.json"} {
+		for i := range release.Assets {
+			if release.Assets[i].Name == name {
+				return &release.Assets[i]
+			}
+		}
+	}
+	return nil
+}
+
+// fetchManifest returns the manifest of asset and the url it came from, nil when the release has none.
+// A manifest that fails verifyManifest is an error.
+func (m *Model) fetchManifest(ctx context.Context, release *github.RepositoryRelease, asset *github.ReleaseAsset) (*manifest.Manifest, string, error) {
+	manAsset := manifestAsset(release, asset)
+	if manAsset == nil {
+		return nil, "", nil
+	}
+
+	data, err := m.fetchAsset(ctx, manAsset.BrowserDownloadURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := m.verifyManifest(ctx, release, manAsset, data); err != nil {
+		return nil, "", err
+	}
+	man, err := manifest.Parse(data)
+	if err != nil {
+		return nil, "", err
+	}
+	return man, manAsset.BrowserDownloadURL, nil
+}
+
+// updateDelta brings the build in gamePath to release with the files of its manifest that changed,
+// patching them when the manifest has a bsdiff from the installed version. The result is staged and
+// swapped in like a full install. It returns false when the caller should download the full archive:
+// no manifest, one without a valid signature, a delta no smaller than the archive, or any failure
+// along the way.
+func (m *Model) updateDelta(ctx context.Context, release *github.RepositoryRelease, asset *github.ReleaseAsset, gamePath, previousTag string) bool {
+	tag := release.TagName
+
+	man, manURL, err := m.fetchManifest(ctx, release, asset)
+	if err != nil {
+		m.logger.Warn().Str(log.Lifecycle, "failed to fetch build manifest, downloading full archive").Str("tag", tag).Err(err).Msg(log.NetworkManager.String())
+		return false
+	}
+	if man == nil || man.BaseURL == "" {
+		m.logger.Info().Str(log.Lifecycle, "no build manifest, downloading full archive").Str("tag", tag).Msg(log.NetworkManager.String())
+		return false
+	}
+	baseURL, err := url.Parse(manURL)
+	if err == nil {
+		baseURL, err = baseURL.Parse(man.BaseURL)
+	}
+	if err != nil {
+		m.logger.Warn().Str(log.Lifecycle, "bad manifest base url").Str("base_url", man.BaseURL).Err(err).Msg(log.NetworkManager.String())
+		return false
+	}
+
+	m.noteProgress(T("model_play.delta_check"))
+	changes, err := manifest.Diff(ctx, m.fs.Root(), gamePath, man)
+	if err != nil {
+		m.logger.Warn().Str(log.Lifecycle, "failed to compare installed build").Err(err).Msg(log.FileManager.String())
+		return false
+	}
+
+	var fetchSize, buildSize int64
+	for _, change := range changes {
+		if patch := change.PatchFrom(change.Current); change.Current != "" && patch != nil {
+			fetchSize += patch.Size
+		} else {
+			fetchSize += change.Size
+		}
+	}
+	for _, f := range man.Files {
+		buildSize += f.Size
+	}
+	if fetchSize >= asset.Size {
+		m.logger.Info().Str(log.Lifecycle, "delta is no smaller than the archive").Int64("delta", fetchSize).Int64("archive", asset.Size).Msg(log.NetworkManager.String())
+		return false
+	}
+	// The staging folder holds a full copy of the build, with room for the patches fetched into it.
+	if !m.checkDiskSpace(uint64(fetchSize + buildSize)) {
+		return false
+	}
+
+	m.logger.Info().Str(log.Lifecycle, "updating from manifest").Str("tag", tag).Int("changed", len(changes)).Int64("bytes", fetchSize).Msg(log.NetworkManager.String())
+
+	stagingPath := gamePath + configs.SuffixStaging
+	if err := m.fs.Root().RemoveAll(stagingPath); err != nil {
+		m.logger.Warn().Str(log.Lifecycle, "failed to remove staging build").Err(err).Msg(log.FileManager.String())
+		return false
+	}
+	if err := m.stageDelta(ctx, tag, baseURL, man, changes, gamePath, stagingPath, fetchSize); err != nil {
+		m.removeBuild(stagingPath)
+		if ctx.Err() == nil {
+			m.logger.Warn().Str(log.Lifecycle, "delta update failed, downloading full archive").Err(err).Msg(log.ErrorManager.String())
+		}
+		return false
+	}
+	if !m.hasGame(stagingPath) {
+		m.removeBuild(stagingPath)
+		m.logger.Warn().Str(log.Lifecycle, "delta update failed, downloading full archive").Err(log.ErrBuildInvalid).Msg(log.ErrorManager.String())
+		return false
+	}
+	if err := m.swapBuild(stagingPath, gamePath, previousTag); err != nil {
+		m.removeBuild(stagingPath)
+		m.logger.Warn().Str(log.Lifecycle, "delta update failed, downloading full archive").Err(err).Msg(log.ErrorManager.String())
+		return false
+	}
+
+	return true
+}
+
+// stageDelta fills stagingPath with every file of man: unchanged ones are copied from gamePath, which
+// becomes the backup, changed ones fetched or patched from baseURL and checked against their sha256.
+func (m *Model) stageDelta(ctx context.Context, tag string, baseURL *url.URL, man *manifest.Manifest, changes []manifest.Change, gamePath, stagingPath string, fetchSize int64) error {
+	root := m.fs.Root()
+
+	changed := make(map[string]manifest.Change, len(changes))
+	for _, change := range changes {
+		changed[change.Path] = change
+	}
+
+	var fetched int64
+	for i, f := range man.Files {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		src := filepath.Join(gamePath, filepath.FromSlash(f.Path))
+		dst := filepath.Join(stagingPath, filepath.FromSlash(f.Path))
+		if err := root.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+
+		change, ok := changed[f.Path]
+		switch {
+		case !ok:
+			if err := m.fs.Copy(src, dst); err != nil {
+				return err
+			}
+		case change.Current != "" && change.PatchFrom(change.Current) != nil:
+			patch := change.PatchFrom(change.Current)
+			if err := m.patchFile(ctx, baseURL.JoinPath(patch.Path).String(), patch, src, dst); err != nil {
+				return fmt.Errorf("%s: %w", f.Path, err)
+			}
+			fetched += patch.Size
+		default:
+			if err := m.fetchFile(ctx, baseURL.JoinPath(f.Path).String(), dst, f.Size); err != nil {
+				return fmt.Errorf("%s: %w", f.Path, err)
+			}
+			fetched += f.Size
+		}
+
+		if ok {
+			if err := checkFile(root, dst, f.SHA256); err != nil {
+				return fmt.Errorf("%s: %w", f.Path, err)
+			}
+		}
+
+		m.publishProgress(ProgressEvent{
+			Phase:      PhaseDownload,
+			Tag:        tag,
+			BytesDone:  fetched,
+			BytesTotal: fetchSize,
+			Limit:      m.downloadLimiter.Limit(),
+			FilesDone:  i + 1,
+			FilesTotal: len(man.Files),
+		})
+	}
+
+	if err := m.makeExecutable(stagingPath); err != nil {
+		return err
+	}
+
+	// Every file was checked against the release manifest, it describes the staged build as is.
+	saved := &manifest.Manifest{Tag: tag, Files: make([]manifest.File, len(man.Files))}
+	for i, f := range man.Files {
+		f.Patches = nil
+		saved.Files[i] = f
+	}
+	return m.saveManifest(stagingPath, saved)
+}
+
+// fetchFile downloads url to dst, a path of Filesystem, with the shared bandwidth limit.
+func (m *Model) fetchFile(ctx context.Context, url, dst string, size int64) error {
+	req, err := grab.NewRequest(filepath.Join(m.fs.Path(), dst), url)
+	if err != nil {
+		return err
+	}
+	req.Size = size
+	req.NoResume = true
+	req.RateLimiter = m.downloadLimiter
+
+	return grab.NewClient().Do(req.WithContext(ctx)).Err()
+}
+
+// patchFile fetches a bsdiff patch next to dst and applies it to src.
+func (m *Model) patchFile(ctx context.Context, url string, patch *manifest.Patch, src, dst string) error {
+	root := m.fs.Root()
+	patchPath := dst + ".bsdiff"
+	defer func() { _ = root.Remove(patchPath) }()
+
+	if err := m.fetchFile(ctx, url, patchPath, patch.Size); err != nil {
+		return err
+	}
+	if err := checkFile(root, patchPath, patch.SHA256); err != nil {
+		return err
+	}
+	data, err := root.ReadFile(patchPath)
+	if err != nil {
+		return err
+	}
+
+	old, err := root.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = old.Close() }()
+	info, err := old.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := root.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = out.Close() }()
+
+	return bsdiff.Apply(out, old, info.Size(), data)
+}
+
+// checkFile compares the sha256 of path with expected.
+func checkFile(root *os.Root, path, expected string) error {
+	file, err := root.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	actual, err := checksum.SHA256(file)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("%w: expected sha256 %s, got %s", log.ErrManifestFile, expected, actual)
+	}
+	return nil
+}
@@ -23,15 +23,22 @@ package p86l
 
 import (
 	"archive/zip"
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"os/signal"
-	"p86l/configs"
+	"p86l/internal/archive"
+	"p86l/internal/channel"
+	"p86l/internal/download"
 	"p86l/internal/github"
 	"p86l/internal/log"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -48,52 +55,143 @@ const (
 	PlayPlay
 )
 
-func (m *Model) downloadGame(gamePath, gameTag string, asset *github.ReleaseAsset) error {
-	client := grab.NewClient()
-	req, _ := grab.NewRequest(gamePath, asset.BrowserDownloadURL)
+// downloadGame tries the release url, then each mirror of DataFile.DownloadMirrors in order.
+// The partial file is kept between attempts, grab only resumes it when the sizes match.
+func (m *Model) downloadGame(ctx context.Context, gamePath, gameTag string, asset *github.ReleaseAsset) error {
+	var errs []error
+
+	for i, url := range AssetURLs(m.Data().Get().DownloadMirrors, gameTag, asset) {
+		if i > 0 {
+			m.noteProgress(T("model_play.mirror"))
+		}
+
+		err := m.downloadGameFrom(ctx, url, gamePath, gameTag, asset)
+		if err == nil {
+			m.logger.Info().Str(log.Lifecycle, "download succeeded").Str("url", url).Msg(log.NetworkManager.String())
+			return nil
+		}
+		// Paused or canceled, the next mirror would not help.
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		m.logger.Warn().Str(log.Lifecycle, "download failed, trying next mirror").Str("url", url).Err(err).Msg(log.ErrorManager.String())
+		errs = append(errs, fmt.Errorf("%s: %w", url, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// downloadProgress is implemented by grab.Response and download.Response.
+type downloadProgress interface {
+	BytesComplete() int64
+	Size() int64
+	BytesPerSecond() float64
+	ETA() time.Time
+	Err() error
+}
+
+// downloadGameFrom fetches url in DataFile.DownloadConnections ranged segments,
+// or with a single grab stream when the server does not support ranges.
+func (m *Model) downloadGameFrom(ctx context.Context, url, gamePath, gameTag string, asset *github.ReleaseAsset) error {
+	m.noteProgress(T("model_play.start"))
+
+	connections := cmp.Or(m.Data().Get().DownloadConnections, download.DefaultConnections)
+	if connections > 1 {
+		downloader := download.New(download.WithConnections(connections), download.WithLimiter(m.downloadLimiter))
+		resp, err := downloader.Do(ctx, url, gamePath, asset.Size)
+		if err == nil {
+			return m.trackDownload(gameTag, resp, resp.Done)
+		}
+		if !errors.Is(err, log.ErrDownloadNoRanges) {
+			return err
+		}
+		m.logger.Info().Str(log.Lifecycle, "no range support, downloading as a single stream").Str("url", url).Msg(log.NetworkManager.String())
+	}
 
-	m.ProgressText(T("model_play.start"))
+	// A preallocated segmented download looks complete to grab.
+	if _, err := os.Stat(gamePath + download.StateSuffix); err == nil {
+		if err := errors.Join(os.Remove(gamePath), os.Remove(gamePath+download.StateSuffix)); err != nil {
+			return err
+		}
+	}
+
+	client := grab.NewClient()
+	req, err := grab.NewRequest(gamePath, url)
+	if err != nil {
+		return err
+	}
+	// Mirrors serving another file are rejected with grab.ErrBadLength.
+	req.Size = asset.Size
+	req.RateLimiter = m.downloadLimiter
+
+	// grab keeps the partial file on cancel, so a paused download resumes from it.
+	resp := client.Do(req.WithContext(ctx))
+	if resp.HTTPResponse != nil {
+		m.noteProgress(resp.HTTPResponse.Status)
+	}
 
-	resp := client.Do(req)
-	m.ProgressText(resp.HTTPResponse.Status)
+	return m.trackDownload(gameTag, resp, resp.Done)
+}
 
+func (m *Model) trackDownload(gameTag string, resp downloadProgress, respDone <-chan struct{}) error {
 	t := time.NewTicker(500 * time.Millisecond)
 	defer t.Stop()
 
 	for done := false; !done; {
 		select {
 		case <-t.C:
-			m.ProgressText(fmt.Sprintf(
-				"%s %s\n\n(%s/%s), %s, %s/s",
-				T("model_play.download"),
-				gameTag,
-				humanize.Bytes(uint64(resp.BytesComplete())),
-				humanize.Bytes(uint64(resp.Size())),
-				humanize.RelTime(time.Now(), resp.ETA(), "remaining", "ago"),
-				humanize.Bytes(uint64(resp.BytesPerSecond())),
-			))
-		case <-resp.Done:
+			m.publishProgress(ProgressEvent{
+				Phase:          PhaseDownload,
+				Tag:            gameTag,
+				BytesDone:      resp.BytesComplete(),
+				BytesTotal:     resp.Size(),
+				BytesPerSecond: resp.BytesPerSecond(),
+				Limit:          m.downloadLimiter.Limit(),
+				ETA:            resp.ETA(),
+			})
+		case <-respDone:
 			done = true
-			m.ProgressText(T("model_play.finished"))
+			if resp.Err() == nil {
+				m.publishProgress(ProgressEvent{
+					Phase:      PhaseDownload,
+					Tag:        gameTag,
+					BytesDone:  resp.Size(),
+					BytesTotal: resp.Size(),
+				})
+			}
 		}
 	}
 
 	return resp.Err()
 }
 
-func (m *Model) unzipGame(zipPath, dest string) error {
+func (m *Model) unzipGame(ctx context.Context, zipPath, dest string) error {
 	r, err := zip.OpenReader(zipPath)
 	if err != nil {
 		return fmt.Errorf("failed to open zip reader: %w", err)
 	}
 	defer func() { _ = r.Close() }()
 
+	if err := archive.Validate(r.File, m.Data().Get().ArchiveLimits); err != nil {
+		return err
+	}
+
 	totalFiles := len(r.File)
+	tag := m.Progress().Tag
 
 	// Extract each files.
 	for i, f := range r.File {
-		progressInt := int(float64(i+1) / float64(totalFiles) * 100)
-		m.ProgressText(fmt.Sprintf("%s %d%%", T("model_play.components"), progressInt))
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		m.publishProgress(ProgressEvent{
+			Phase:      PhaseExtract,
+			Tag:        tag,
+			FilesDone:  i + 1,
+			FilesTotal: totalFiles,
+		})
 
 		err := zipExtractFile(m.fs.Root(), dest, f)
 		if err != nil {
@@ -101,9 +199,44 @@ func (m *Model) unzipGame(zipPath, dest string) error {
 		}
 	}
 
+	return m.makeExecutable(dest)
+}
+
+// makeExecutable sets the exec bit of the game in gamePath, zips made on Windows carry no permissions.
+func (m *Model) makeExecutable(gamePath string) error {
+	goos := m.GameOS()
+	if goos == "windows" {
+		return nil
+	}
+
+	gameFile := filepath.Join(gamePath, GameFile(goos))
+	if err := m.fs.Root().Chmod(gameFile, 0755); err != nil {
+		return fmt.Errorf("failed to make %s executable: %w", gameFile, err)
+	}
 	return nil
 }
 
+// failUnzip reports an extraction error, an archive rejected as unsafe is quarantined like a bad checksum.
+func (m *Model) failUnzip(zipPath string, err error) {
+	var archiveErr *archive.Error
+	if !errors.As(err, &archiveErr) {
+		mErr := T("model_play.fail_unzip")
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Caller().Msg(log.ErrorManager.String())
+		return
+	}
+
+	quarantinePath, qErr := m.quarantine(zipPath)
+	mErr := T("model_play.fail_archive")
+	m.failProgress(mErr, archiveErr)
+	m.logger.Warn().
+		Str(log.Lifecycle, strings.ToLower(mErr)).
+		Str("entry", archiveErr.Name).
+		Str("quarantine", quarantinePath).
+		Err(errors.Join(err, qErr)).
+		Msg(log.ErrorManager.String())
+}
+
 func zipExtractFile(fs *os.Root, dest string, f *zip.File) error {
 	relPath := filepath.Join(dest, f.Name)
 
@@ -121,8 +254,14 @@ func zipExtractFile(fs *os.Root, dest string, f *zip.File) error {
 		mode = 0644
 	}
 
+	// Repair extracts over installed files, they are replaced rather than written through
+	// in case they are linked elsewhere.
+	if err := fs.Remove(relPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
 	// Create file.
-	outFile, err := fs.OpenFile(relPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	outFile, err := fs.OpenFile(relPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
 	if err != nil {
 		return err
 	}
@@ -172,154 +311,275 @@ func zipMkdirAll(root *os.Root, path string, perm os.FileMode) error {
 	return err
 }
 
-func (m *Model) installOrUpdate(isUpdate bool) {
-	dataFile := m.Data().Get()
-	cacheFile := m.Cache().Get()
+// installStep is where an install resumes after a pause.
+type installStep int
+
+const (
+	stepDelta installStep = iota
+	stepDownload
+	stepVerify
+	stepExtract
+)
 
-	var downloadRelease *github.RepositoryRelease
-	var gamePath, gameTag, zipPath string
-	var downloadAsset *github.ReleaseAsset
-	var resumeVersion string
+// installJob is a release resolved by installOrUpdate, installVersion or repair.
+// A paused job resumes from its step with the same release, even if a newer one came out.
+type installJob struct {
+	release     *github.RepositoryRelease
+	asset       *github.ReleaseAsset
+	gamePath    string
+	zipPath     string
+	previousTag string
+	// Tries the files of the release manifest before the full archive.
+	delta bool
+	// Only these files are extracted over gamePath when set, see Repair.
+	files map[string]bool
+	// Records the build once it is in place.
+	done func()
+}
 
-	if cacheFile.Releases == nil {
-		err := T("model_play.missing_releases")
-		m.ProgressText(err)
-		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(err)).Msg(log.NetworkManager.String())
-		return
+// runJob runs job from step, each step makes itself the one a resumed task starts from.
+func (m *Model) runJob(ctx context.Context, job *installJob, step installStep) {
+	tag := job.release.TagName
+
+	for ; step <= stepExtract; step++ {
+		m.setResume(func(ctx context.Context) { m.runJob(ctx, job, step) })
+
+		switch step {
+		case stepDelta:
+			if !job.delta {
+				continue
+			}
+			if m.updateDelta(ctx, job.release, job.asset, job.gamePath, job.previousTag) {
+				m.logger.Info().Str(log.Lifecycle, "game update done").Str("tag", tag).Msg(log.FileManager.String())
+				job.done()
+				return
+			}
+			if m.taskStopped(ctx, job.zipPath) {
+				return
+			}
+		case stepDownload:
+			if !m.checkDiskSpace(m.downloadSpace(job.asset, job.zipPath)) {
+				return
+			}
+
+			m.logger.Info().Str(log.Lifecycle, fmt.Sprintf("downloading file to %s", filepath.Join(m.fs.Path(), job.zipPath))).Msg(log.FileManager.String())
+			if err := m.downloadGame(ctx, filepath.Join(m.fs.Path(), job.zipPath), tag, job.asset); err != nil {
+				if m.taskStopped(ctx, job.zipPath) {
+					return
+				}
+				m.failProgress(T("model_play.fail_asset"), err)
+				m.logger.Warn().
+					Str(log.Lifecycle, fmt.Sprintf("failed to download %s", tag)).
+					Err(err).
+					Caller().
+					Msg(log.ErrorManager.String())
+				return
+			}
+			time.Sleep(2 * time.Second)
+		case stepVerify:
+			if !m.verifyGame(ctx, job.release, job.asset, job.zipPath) {
+				m.taskStopped(ctx, job.zipPath)
+				return
+			}
+		case stepExtract:
+			if !m.extractJob(ctx, job) {
+				return
+			}
+		}
 	}
 
-	if dataFile.UsePreRelease {
-		downloadRelease = cacheFile.Releases.PreRelease
-		gameAsset := GetAssets(downloadRelease.Assets)
-		downloadAsset = gameAsset
-		resumeVersion = dataFile.PreReleaseVersion
+	m.publishProgress(ProgressEvent{Phase: PhaseCleanup, Tag: tag})
+	m.discardDownload(job.zipPath)
+	job.done()
+}
 
-		gamePath = filepath.Join(configs.FolderBuilds, configs.FolderPreRelease)
+// extractJob unzips the verified archive of job, the whole build next to gamePath or only job.files over it.
+func (m *Model) extractJob(ctx context.Context, job *installJob) bool {
+	tag := job.release.TagName
+
+	if !m.checkExtractSpace(job) {
+		return false
+	}
+	m.noteProgress(T("model_play.install_unzip"))
+
+	var err error
+	if job.files == nil {
+		// Unzip the files next to the build and swap them in, the old build stays until the new one is complete.
+		m.logger.Info().Str(log.Lifecycle, "unzipping files").Str("tag", tag).Msg(log.FileManager.String())
+		err = m.installBuild(ctx, job.zipPath, job.gamePath, tag, job.previousTag)
 	} else {
-		downloadRelease = cacheFile.Releases.Stable
-		gameAsset := GetAssets(downloadRelease.Assets)
-		downloadAsset = gameAsset
-		resumeVersion = dataFile.GameVersion
+		m.logger.Info().Str(log.Lifecycle, "extracting files to repair").Str("tag", tag).Int("files", len(job.files)).Msg(log.FileManager.String())
+		err = m.extractFiles(ctx, filepath.Join(m.fs.Path(), job.zipPath), job.gamePath, job.files)
+	}
+	if err != nil {
+		if m.taskStopped(ctx, job.zipPath) {
+			return false
+		}
+		m.failUnzip(job.zipPath, err)
+		return false
+	}
+
+	return true
+}
 
-		gamePath = filepath.Join(configs.FolderBuilds, configs.FolderStable)
+// installDone is the done of an installJob for tag, record saves where the build went.
+func (m *Model) installDone(tag string, record func(df *DataFile)) func() {
+	return func() {
+		m.Data().Update(record)
+		m.logger.Info().Str(log.Lifecycle, "game installation done").Str("tag", tag).Msg(log.FileManager.String())
+		m.publishProgress(ProgressEvent{Phase: PhaseDone, Tag: tag})
+		time.Sleep(2 * time.Second)
 	}
+}
 
-	gameTag = downloadRelease.TagName
+func (m *Model) installOrUpdate(ctx context.Context, isUpdate bool) {
+	c := m.Channel()
+	state := m.Data().Get().ChannelState(c.Name)
 
-	if dataFile.UsePreRelease {
-		zipPath = filepath.Join(configs.FolderTemp, configs.FilePrereleaseZip)
-	} else {
-		zipPath = filepath.Join(configs.FolderTemp, configs.FileStableZip)
+	downloadRelease := c.Latest(m.Cache().Get().AllReleases)
+	if downloadRelease == nil {
+		err := T("model_play.missing_releases")
+		m.failProgress(err, nil)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(err)).Str("channel", c.Name).Msg(log.NetworkManager.String())
+		return
 	}
 
+	downloadAsset, assetErr := m.GameAsset(downloadRelease)
+	resumeVersion := state.Pending
+	gamePath := c.Path()
+	gameTag := downloadRelease.TagName
+	zipPath := PathBuildZip(gamePath)
+
 	// Will delete the game file that's partially downloaded, if a newer version of game came out.
 	// Issues are practically rare here, since GUI will not allow this to be executed after Install is done.
 	if resumeVersion != "" {
 		isNew, err := IsNewVersion(resumeVersion, downloadRelease.TagName)
 		if err != nil {
 			mErr := T("model_play.unknown_version")
-			m.ProgressText(fmt.Sprintf("%s: %v", mErr, err))
+			m.failProgress(mErr, err)
 			m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
 			return
 		}
 
+		if isNew && m.fs.Exist(zipPath+download.StateSuffix) {
+			if err := m.fs.Remove(zipPath + download.StateSuffix); err != nil {
+				mErr := T("model_play.fail_resume")
+				m.failProgress(mErr, err)
+				m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
+				return
+			}
+		}
 		if isNew && m.fs.Exist(zipPath) {
 			if err := m.fs.Remove(zipPath); err != nil {
 				mErr := T("model_play.fail_resume")
-				m.ProgressText(fmt.Sprintf("%s: %v", mErr, err))
+				m.failProgress(mErr, err)
 				m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
 				return
 			}
 		}
 	}
 
-	if downloadAsset == nil {
-		err := T("model_play.missing_asset")
-		m.ProgressText(err)
-		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(err)).Msg(log.NetworkManager.String())
+	if assetErr != nil {
+		mErr := T("model_play.missing_asset")
+		m.failProgress(mErr, assetErr)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(assetErr).Msg(log.NetworkManager.String())
 		return
 	}
+
 	m.Data().Update(func(df *DataFile) {
-		if dataFile.UsePreRelease {
-			df.PreReleaseVersion = downloadRelease.TagName
-		} else {
-			df.GameVersion = downloadRelease.TagName
-		}
+		df.UpdateChannelState(c.Name, func(s *channel.State) { s.Pending = gameTag })
 	})
 
-	// Download builds.
-	m.logger.Info().Str(log.Lifecycle, fmt.Sprintf("downloading file to %s", filepath.Join(m.fs.Path(), zipPath))).Msg(log.FileManager.String())
-	if err := m.downloadGame(filepath.Join(m.fs.Path(), zipPath), gameTag, downloadAsset); err != nil {
-		m.ProgressText(fmt.Sprintf("%s %v", T("model_play.fail_asset"), err))
-		m.logger.Warn().
-			Str(log.Lifecycle, fmt.Sprintf("failed to download %s", gameTag)).
-			Err(err).
-			Caller().
-			Msg(log.ErrorManager.String())
-		return
-	}
-	time.Sleep(2 * time.Second)
-
-	m.ProgressText(T("model_play.install_unzip"))
-	time.Sleep(2 * time.Second)
-
-	// Removes builds if there is a update.
-	if isUpdate {
-		var updatePath string
-		if dataFile.UsePreRelease {
-			updatePath = filepath.Join(configs.FolderBuilds, configs.FolderPreRelease)
-		} else {
-			updatePath = filepath.Join(configs.FolderBuilds, configs.FolderStable)
-		}
+	m.runJob(ctx, &installJob{
+		release:     downloadRelease,
+		asset:       downloadAsset,
+		gamePath:    gamePath,
+		zipPath:     zipPath,
+		previousTag: state.Installed,
+		// Updates only fetch the files that changed when the release has a manifest.
+		delta: isUpdate && m.hasGame(gamePath),
+		done: m.installDone(gameTag, func(df *DataFile) {
+			df.UpdateChannelState(c.Name, func(s *channel.State) { s.Installed = gameTag })
+		}),
+	}, stepDelta)
+}
 
-		if err := m.fs.Root().RemoveAll(updatePath); err != nil {
-			mErr := T("model_play.fail_update")
-			m.ProgressText(fmt.Sprintf("%s: %v", mErr, err))
-			m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Caller().Msg(log.ErrorManager.String())
-			return
-		}
+func (m *Model) installVersion(ctx context.Context, tag string) {
+	cacheFile := m.Cache().Get()
 
+	release := FindRelease(cacheFile, tag)
+	if release == nil {
+		err := T("model_play.missing_releases")
+		m.failProgress(err, nil)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(err)).Str("tag", tag).Msg(log.NetworkManager.String())
+		return
 	}
 
-	// Unzip the files to builds.
-	m.logger.Info().Str(log.Lifecycle, "unzipping files").Msg(log.FileManager.String())
-	if err := m.unzipGame(filepath.Join(m.fs.Path(), zipPath), gamePath); err != nil {
-		mErr := T("model_play.fail_unzip")
-		m.ProgressText(fmt.Sprintf("%s: %v", mErr, err))
-		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Caller().Msg(log.ErrorManager.String())
+	downloadAsset, err := m.GameAsset(release)
+	if err != nil {
+		mErr := T("model_play.missing_asset")
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Str("tag", tag).Err(err).Msg(log.NetworkManager.String())
 		return
 	}
 
-	m.Data().Update(func(df *DataFile) {
-		if dataFile.UsePreRelease {
-			df.InstalledPreRelease = downloadRelease.TagName
-		} else {
-			df.InstalledGame = downloadRelease.TagName
+	gamePath := PathBuildVersion(tag)
+
+	// The zip is named after the tag so the download can resume it.
+	m.runJob(ctx, &installJob{
+		release:     release,
+		asset:       downloadAsset,
+		gamePath:    gamePath,
+		zipPath:     PathBuildZip(gamePath),
+		previousTag: m.installedTag(gamePath),
+		done: m.installDone(tag, func(df *DataFile) {
+			df.PinnedVersion = tag
+		}),
+	}, stepDownload)
+}
+
+// gameCommand runs exePath directly, or through DataFile.Runner with a WINEPREFIX per build.
+func (m *Model) gameCommand(exePath string) (*exec.Cmd, error) {
+	dataFile := m.Data().Get()
+	path := filepath.Join(m.fs.Path(), exePath)
+
+	var cmd *exec.Cmd
+	if runner := dataFile.Runner; len(runner) > 0 && runtime.GOOS != "windows" {
+		prefix := dataFile.RunnerPrefix
+		if prefix == "" {
+			relPrefix := PathRunnerPrefix(filepath.Dir(exePath))
+			if err := m.fs.Root().MkdirAll(relPrefix, 0755); err != nil {
+				return nil, err
+			}
+			prefix = filepath.Join(m.fs.Path(), relPrefix)
 		}
-	})
-	if m.fs.Exist(zipPath) {
-		if err := m.fs.Remove(zipPath); err != nil {
-			mErr := T("model_play.fail_artifact")
-			m.ProgressText(fmt.Sprintf("%s: %v", mErr, err))
-			m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
-			return
+
+		cmd = exec.Command(runner[0], append(runner[1:], path)...)
+		cmd.Env = append(os.Environ(), "WINEPREFIX="+prefix)
+		// Proton keeps its prefix under STEAM_COMPAT_DATA_PATH instead.
+		if slices.ContainsFunc(runner, func(arg string) bool {
+			return strings.Contains(strings.ToLower(filepath.Base(arg)), "proton")
+		}) {
+			cmd.Env = append(cmd.Env, "STEAM_COMPAT_DATA_PATH="+prefix)
+		}
+		// Set last so they can override the ones above.
+		for key, value := range dataFile.RunnerEnv {
+			cmd.Env = append(cmd.Env, key+"="+value)
 		}
+
+		m.logger.Info().Strs("runner", runner).Str("prefix", prefix).Msg(log.AppManager.String())
+	} else {
+		cmd = exec.Command(path)
 	}
-	m.logger.Info().Str(log.Lifecycle, "game installation done").Msg(log.FileManager.String())
-	m.ProgressText(T("model_play.install_finished"))
-	time.Sleep(2 * time.Second)
+
+	// Builds load their data folder relative to the working directory.
+	cmd.Dir = filepath.Dir(path)
+	return cmd, nil
 }
 
 func (m *Model) handlePlay() {
-	var exePath string
 	data := m.Data()
-	dataFile := data.Get()
-
-	if dataFile.UsePreRelease {
-		exePath = PathGamePreRelease
-	} else {
-		exePath = PathGameStable
-	}
+	exePath := filepath.Join(m.buildPath(), GameFile(m.GameOS()))
 
 	if ok := m.CheckFilesCached(exePath); !ok {
 		// Failed to find exe
@@ -327,10 +587,11 @@ func (m *Model) handlePlay() {
 		return
 	}
 
-	path := filepath.Join(m.fs.Path(), exePath)
-
-	// TODO: proper linux support?
-	cmd := exec.Command(path)
+	cmd, err := m.gameCommand(exePath)
+	if err != nil {
+		m.logger.Info().Str("Path", exePath).Err(err).Msg("can't prepare runner?")
+		return
+	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -354,11 +615,15 @@ func (m *Model) handlePlay() {
 
 	for {
 		select {
-		case <-done:
+		case err := <-done:
 			sessionTime := time.Since(startTime)
 			data.Update(func(df *DataFile) {
 				df.TotalPlayTime += sessionTime
 			})
+			// The updated build works, its backup is no longer needed.
+			if err == nil || sessionTime >= backupLaunchTime {
+				m.dropBackup(filepath.Dir(exePath))
+			}
 			m.logger.Info().Str("Exited after", humanize.RelTime(time.Now(), time.Now().Add(sessionTime), "", "")).Msg(log.AppManager.String())
 			return
 		case <-sigChan:
@@ -375,20 +640,32 @@ func (m *Model) handlePlay() {
 }
 
 func (m *Model) Play(playType PlayType) {
-	m.InProgress(true)
-	defer m.InProgress(false)
-
 	switch playType {
-	case PlayInstall:
-		m.installOrUpdate(false)
-	case PlayUpdate:
-		m.installOrUpdate(true)
+	case PlayInstall, PlayUpdate:
+		m.runTask(func(ctx context.Context) { m.installOrUpdate(ctx, playType == PlayUpdate) })
 	case PlayPlay:
+		m.InProgress(true)
+		defer m.InProgress(false)
 		m.handlePlay()
 	default:
 		return
 	}
 
-	m.ProgressText("")
 	m.logger.Info().Str(log.Lifecycle, "Model.Play is finished").Msg(log.NetworkManager.String())
 }
+
+// InstallVersion installs a specific release into its own build folder and pins it,
+// so updates of stable/pre-release leave it alone.
+func (m *Model) InstallVersion(tag string) {
+	m.runTask(func(ctx context.Context) { m.installVersion(ctx, tag) })
+	m.logger.Info().Str(log.Lifecycle, "Model.InstallVersion is finished").Msg(log.NetworkManager.String())
+}
+
+// PinVersion selects the version Play launches, empty unpins.
+func (m *Model) PinVersion(tag string) {
+	m.Data().Update(func(df *DataFile) {
+		df.PinnedVersion = tag
+	})
+	m.logger.Info().Str(log.Lifecycle, "pinned version").Str("tag", tag).Msg(log.AppManager.String())
+	m.handleUIRefresh()
+}
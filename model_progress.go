@@ -0,0 +1,196 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+type ProgressPhase int
+
+const (
+	PhaseIdle ProgressPhase = iota
+	PhaseDownload
+	PhaseVerify
+	PhaseExtract
+	PhaseCleanup
+	// Final phases, kept until the next task starts.
+	PhaseDone
+	PhasePaused
+	PhaseCanceled
+	PhaseFailed
+)
+
+// IsFinal reports whether the task publishing it has stopped.
+func (p ProgressPhase) IsFinal() bool {
+	return p >= PhaseDone
+}
+
+// ProgressEvent is published by installs and updates, see Model.SubscribeProgress.
+type ProgressEvent struct {
+	Phase ProgressPhase
+	Tag   string
+	// PhaseDownload
+	BytesDone, BytesTotal int64
+	BytesPerSecond        float64
+	Limit                 int64 // 0 is unlimited
+	ETA                   time.Time
+	// PhaseExtract
+	FilesDone, FilesTotal int
+	// Shown in place of the text of the phase, e.g. the result of a file check
+	// or what failed for PhaseFailed. Localized.
+	Message string
+	// PhaseFailed, the cause of the failure.
+	Err error
+}
+
+// Fraction is how far the current phase is, from 0 to 1.
+func (e ProgressEvent) Fraction() float64 {
+	switch {
+	case e.Phase == PhaseDone:
+		return 1
+	case e.Phase == PhaseExtract && e.FilesTotal > 0:
+		return float64(e.FilesDone) / float64(e.FilesTotal)
+	case e.BytesTotal > 0:
+		return float64(e.BytesDone) / float64(e.BytesTotal)
+	}
+	return 0
+}
+
+// Rate renders the download speed with the bandwidth limit.
+func (e ProgressEvent) Rate() string {
+	rate := fmt.Sprintf("%s/s", humanize.Bytes(uint64(e.BytesPerSecond)))
+	if e.Limit > 0 {
+		rate = fmt.Sprintf("%s (%s %s/s)", rate, T("model_play.limit"), humanize.Bytes(uint64(e.Limit)))
+	}
+	return rate
+}
+
+// Remaining renders the time left of the download.
+func (e ProgressEvent) Remaining() string {
+	return humanize.RelTime(time.Now(), e.ETA, T("progress.remaining"), T("progress.ago"))
+}
+
+// String is the localized text shown by ProgressText.
+func (e ProgressEvent) String() string {
+	switch {
+	case e.Phase == PhaseFailed && e.Err != nil:
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	case e.Message != "":
+		return e.Message
+	}
+
+	switch e.Phase {
+	case PhaseDownload:
+		if e.BytesTotal > 0 && e.BytesDone >= e.BytesTotal {
+			return T("model_play.finished")
+		}
+		return fmt.Sprintf(
+			"%s %s\n\n(%s/%s), %s, %s",
+			T("model_play.download"),
+			e.Tag,
+			humanize.Bytes(uint64(e.BytesDone)),
+			humanize.Bytes(uint64(e.BytesTotal)),
+			e.Remaining(),
+			e.Rate(),
+		)
+	case PhaseVerify:
+		return T("model_play.verify")
+	case PhaseExtract:
+		return fmt.Sprintf("%s %d%%", T("model_play.components"), int(e.Fraction()*100))
+	case PhaseCleanup:
+		return T("model_play.cleanup")
+	case PhaseDone:
+		return T("model_play.install_finished")
+	case PhasePaused:
+		return T("model_play.paused")
+	case PhaseCanceled:
+		return T("model_play.canceled")
+	}
+	return ""
+}
+
+// Progress returns the last published event.
+func (m *Model) Progress() ProgressEvent {
+	m.progressMutex.RLock()
+	defer m.progressMutex.RUnlock()
+	return m.progress
+}
+
+// SubscribeProgress calls fn with every event.
+// fn runs on the publishing goroutine and must not block.
+func (m *Model) SubscribeProgress(fn func(ProgressEvent)) {
+	m.progressMutex.Lock()
+	defer m.progressMutex.Unlock()
+	m.progressSubs = append(m.progressSubs, fn)
+}
+
+func (m *Model) publishProgress(event ProgressEvent) {
+	m.progressMutex.Lock()
+	m.progress = event
+	subs := slices.Clone(m.progressSubs)
+	refreshFn := m.progressRefreshFn
+	m.progressMutex.Unlock()
+
+	for _, fn := range subs {
+		fn(event)
+	}
+	if refreshFn != nil {
+		refreshFn()
+	}
+}
+
+// noteProgress shows message in place of the text of the current phase.
+func (m *Model) noteProgress(message string) {
+	last := m.Progress()
+	m.publishProgress(ProgressEvent{Phase: last.Phase, Tag: last.Tag, Message: message})
+}
+
+// failProgress ends the task as PhaseFailed, message is the localized error and err its cause, if any.
+func (m *Model) failProgress(message string, err error) {
+	m.publishProgress(ProgressEvent{
+		Phase:   PhaseFailed,
+		Tag:     m.Progress().Tag,
+		Message: message,
+		Err:     err,
+	})
+}
+
+// ClearProgress dismisses the summary of the last task.
+func (m *Model) ClearProgress() {
+	if !m.Progress().Phase.IsFinal() {
+		return
+	}
+	m.resetProgress()
+	m.handleUIRefresh()
+}
+
+// resetProgress forgets the event of the previous task without publishing.
+func (m *Model) resetProgress() {
+	m.progressMutex.Lock()
+	defer m.progressMutex.Unlock()
+	m.progress = ProgressEvent{}
+}
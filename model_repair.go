@@ -0,0 +1,280 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"p86l/configs"
+	"p86l/internal/archive"
+	"p86l/internal/log"
+	"p86l/internal/manifest"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileCheck is the result of VerifyFiles for a build folder.
+type FileCheck struct {
+	Path     string
+	Tag      string
+	Missing  []string
+	Modified []string
+}
+
+func (c *FileCheck) OK() bool {
+	return len(c.Missing) == 0 && len(c.Modified) == 0
+}
+
+// String sums the check up, e.g. "2 missing, 1 modified".
+func (c *FileCheck) String() string {
+	if c.OK() {
+		return T("model_play.files_ok")
+	}
+	return fmt.Sprintf("%d %s, %d %s", len(c.Missing), T("model_play.files_missing"), len(c.Modified), T("model_play.files_modified"))
+}
+
+// saveManifest records the files of the build in dir, read back by VerifyFiles and Repair.
+func (m *Model) saveManifest(dir string, man *manifest.Manifest) error {
+	data, err := man.Marshal()
+	if err != nil {
+		return err
+	}
+	return m.fs.Save(filepath.Join(dir, configs.FileBuildManifest), data)
+}
+
+// hashBuild saves the manifest of a freshly extracted build, a failure only costs verify and repair.
+func (m *Model) hashBuild(dir, tag string) {
+	man, err := manifest.Hash(m.fs.Root(), dir, tag)
+	if err == nil {
+		err = m.saveManifest(dir, man)
+	}
+	if err != nil {
+		m.logger.Warn().Str(log.Lifecycle, "failed to save build manifest").Str("path", filepath.ToSlash(dir)).Err(err).Msg(log.ErrorManager.String())
+	}
+}
+
+func (m *Model) loadManifest(dir string) (*manifest.Manifest, error) {
+	path := filepath.Join(dir, configs.FileBuildManifest)
+	if !m.fs.Exist(path) {
+		return nil, log.ErrManifestMissing
+	}
+	data, err := m.fs.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return manifest.Parse(data)
+}
+
+// checkFiles compares the build in gamePath with the manifest saved when it was installed.
+func (m *Model) checkFiles(ctx context.Context, gamePath string) (*FileCheck, []manifest.Change, *manifest.Manifest, error) {
+	man, err := m.loadManifest(gamePath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	changes, err := manifest.Diff(ctx, m.fs.Root(), gamePath, man)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	check := &FileCheck{Path: gamePath, Tag: man.Tag}
+	for _, change := range changes {
+		if change.Current == "" {
+			check.Missing = append(check.Missing, change.Path)
+		} else {
+			check.Modified = append(check.Modified, change.Path)
+		}
+	}
+	return check, changes, man, nil
+}
+
+// FileCheck returns the last VerifyFiles result of the build Play launches, or nil.
+func (m *Model) FileCheck() *FileCheck {
+	m.fileCheckMutex.RLock()
+	defer m.fileCheckMutex.RUnlock()
+
+	if m.fileCheck == nil || m.fileCheck.Path != m.buildPath() {
+		return nil
+	}
+	return m.fileCheck
+}
+
+func (m *Model) setFileCheck(check *FileCheck) {
+	m.fileCheckMutex.Lock()
+	m.fileCheck = check
+	m.fileCheckMutex.Unlock()
+
+	m.handleUIRefresh()
+}
+
+// VerifyFiles hashes the build Play launches and lists its missing or modified files, see FileCheck.
+func (m *Model) VerifyFiles() {
+	m.runTask(m.verifyFiles)
+	m.logger.Info().Str(log.Lifecycle, "Model.VerifyFiles is finished").Msg(log.NetworkManager.String())
+}
+
+func (m *Model) verifyFiles(ctx context.Context) {
+	gamePath := m.buildPath()
+	m.noteProgress(T("model_play.verify_files"))
+
+	check, _, _, err := m.checkFiles(ctx, gamePath)
+	if err != nil {
+		m.setFileCheck(nil)
+		if m.taskStopped(ctx, "") {
+			return
+		}
+		mErr := T("model_play.fail_files")
+		if errors.Is(err, log.ErrManifestMissing) {
+			mErr = T("model_play.no_manifest")
+		}
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Str("path", filepath.ToSlash(gamePath)).Err(err).Msg(log.ErrorManager.String())
+		return
+	}
+
+	m.setFileCheck(check)
+	m.publishProgress(ProgressEvent{Phase: PhaseDone, Message: check.String()})
+	m.logger.Info().
+		Str(log.Lifecycle, "verified installed files").
+		Str("path", filepath.ToSlash(gamePath)).
+		Strs("missing", check.Missing).
+		Strs("modified", check.Modified).
+		Msg(log.FileManager.String())
+}
+
+// Repair downloads the archive of the build Play launches again and extracts the files VerifyFiles found broken.
+func (m *Model) Repair() {
+	m.runTask(m.repair)
+	m.logger.Info().Str(log.Lifecycle, "Model.Repair is finished").Msg(log.NetworkManager.String())
+}
+
+func (m *Model) repair(ctx context.Context) {
+	gamePath := m.buildPath()
+
+	fail := func(mErr string, err error) {
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Str("path", filepath.ToSlash(gamePath)).Err(err).Msg(log.ErrorManager.String())
+	}
+
+	m.noteProgress(T("model_play.verify_files"))
+	check, changes, man, err := m.checkFiles(ctx, gamePath)
+	if err != nil {
+		switch {
+		case m.taskStopped(ctx, ""):
+		case errors.Is(err, log.ErrManifestMissing):
+			fail(T("model_play.no_manifest"), err)
+		default:
+			fail(T("model_play.fail_files"), err)
+		}
+		return
+	}
+	m.setFileCheck(check)
+	if check.OK() {
+		m.publishProgress(ProgressEvent{Phase: PhaseDone, Message: check.String()})
+		return
+	}
+
+	release := FindRelease(m.Cache().Get(), man.Tag)
+	if release == nil {
+		fail(T("model_play.missing_releases"), fmt.Errorf("%w: %s", log.ErrManifestInvalid, man.Tag))
+		return
+	}
+	downloadAsset, err := m.GameAsset(release)
+	if err != nil {
+		fail(T("model_play.missing_asset"), err)
+		return
+	}
+
+	paths := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		paths[change.Path] = true
+	}
+
+	m.logger.Info().Str(log.Lifecycle, "downloading archive for repair").Str("tag", man.Tag).Int("files", len(changes)).Msg(log.NetworkManager.String())
+	m.runJob(ctx, &installJob{
+		release:  release,
+		asset:    downloadAsset,
+		gamePath: gamePath,
+		zipPath:  PathRepairZip(gamePath, man.Tag),
+		files:    paths,
+		done: func() {
+			check, _, _, err := m.checkFiles(ctx, gamePath)
+			if err != nil {
+				fail(T("model_play.fail_files"), err)
+				return
+			}
+			m.setFileCheck(check)
+			if !check.OK() {
+				// The archive does not hold the files the manifest lists.
+				fail(T("model_play.fail_repair"), fmt.Errorf("%w: %s", log.ErrManifestFile, check))
+				return
+			}
+
+			m.logger.Info().Str(log.Lifecycle, "repair done").Str("tag", man.Tag).Msg(log.FileManager.String())
+			m.publishProgress(ProgressEvent{Phase: PhaseDone, Tag: man.Tag, Message: T("model_play.repair_finished")})
+			time.Sleep(2 * time.Second)
+		},
+	}, stepDownload)
+}
+
+// extractFiles extracts the entries of zipPath listed in paths into dest, over the files there.
+func (m *Model) extractFiles(ctx context.Context, zipPath, dest string, paths map[string]bool) error {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("failed to open zip reader: %w", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	if err := archive.Validate(r.File, m.Data().Get().ArchiveLimits); err != nil {
+		return err
+	}
+
+	tag := m.Progress().Tag
+	done := 0
+	for _, f := range r.File {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		if !paths[strings.ReplaceAll(f.Name, "\\", "/")] {
+			continue
+		}
+
+		if err := zipExtractFile(m.fs.Root(), dest, f); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", f.Name, err)
+		}
+
+		done++
+		m.publishProgress(ProgressEvent{
+			Phase:      PhaseExtract,
+			Tag:        tag,
+			FilesDone:  done,
+			FilesTotal: len(paths),
+		})
+	}
+
+	if paths[GameFile(m.GameOS())] {
+		return m.makeExecutable(dest)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"p86l/internal/download"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// zipUncompressedSize is the space the entries of the archive take once extracted,
+// only the ones in files when it is set.
+func zipUncompressedSize(zipPath string, files map[string]bool) (uint64, error) {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open zip reader: %w", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	var total uint64
+	for _, f := range r.File {
+		if files != nil && !files[strings.ReplaceAll(f.Name, "\\", "/")] {
+			continue
+		}
+		total += f.UncompressedSize64
+	}
+	return total, nil
+}
+
+// downloadSpace is the part of the archive still to download. What extraction needs
+// is only known from the archive itself, see checkExtractSpace.
+func (m *Model) downloadSpace(asset *github.ReleaseAsset, zipPath string) uint64 {
+	path := filepath.Join(m.fs.Path(), zipPath)
+	if remaining, err := download.Remaining(path); err == nil {
+		return uint64(remaining)
+	}
+
+	remaining := asset.Size
+	if info, err := os.Stat(path); err == nil {
+		remaining = max(asset.Size-info.Size(), 0)
+	}
+	return uint64(remaining)
+}
+
+// checkDiskSpace refuses to go on when the volume of Filesystem.Path has less than needed bytes free.
+// It lets the install go on when free space cannot be queried.
+func (m *Model) checkDiskSpace(needed uint64) bool {
+	free, err := m.fs.FreeSpace()
+	if err != nil {
+		m.logger.Warn().Str(log.Lifecycle, "could not check disk space").Err(err).Msg(log.ErrorManager.String())
+		return true
+	}
+
+	if free >= needed {
+		m.logger.Info().Uint64("needed", needed).Uint64("free", free).Msg(log.FileManager.String())
+		return true
+	}
+
+	mErr := T("model_play.fail_space")
+	m.failProgress(fmt.Sprintf("%s: %s %s, %s %s", mErr, humanize.Bytes(needed), T("model_play.space_needed"), humanize.Bytes(free), T("model_play.space_free")), nil)
+	m.logger.Warn().
+		Str(log.Lifecycle, strings.ToLower(mErr)).
+		Uint64("needed", needed).
+		Uint64("free", free).
+		Err(log.ErrDiskSpace).
+		Msg(log.ErrorManager.String())
+	return false
+}
+
+// checkExtractSpace checks free space before extractJob, from the uncompressed size of the entries
+// it writes. A full install extracts a whole copy of the build into the staging folder while the
+// installed build, which becomes the backup, and the previous backup stay on disk until the swap.
+// A repair only writes the files it replaces.
+func (m *Model) checkExtractSpace(job *installJob) bool {
+	needed, err := zipUncompressedSize(filepath.Join(m.fs.Path(), job.zipPath), job.files)
+	if err != nil {
+		mErr := T("model_play.fail_unzip")
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
+		return false
+	}
+	return m.checkDiskSpace(needed)
+}
@@ -0,0 +1,196 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"context"
+	"errors"
+	"p86l/internal/download"
+	"p86l/internal/log"
+	"strings"
+)
+
+// task is an install, update, repair, rollback or file check, only one runs at a time.
+// Installs can be paused, resumed or canceled.
+type task struct {
+	cancel context.CancelCauseFunc
+	// Continues the task from the step it was stopped at, see setResume.
+	resume func(ctx context.Context)
+	// Drops the partial download of a paused task.
+	discard func()
+}
+
+// runTask runs fn as the task, it reports false without running fn while another task runs.
+// A paused task is replaced, its partial download is picked up by the next install.
+func (m *Model) runTask(fn func(ctx context.Context)) bool {
+	ctx, cancel := context.WithCancelCause(m.ctx)
+
+	m.taskMutex.Lock()
+	if m.task != nil {
+		m.taskMutex.Unlock()
+		cancel(nil)
+		m.logger.Info().Str(log.Lifecycle, "another task is running").Msg(log.AppManager.String())
+		return false
+	}
+	m.task = &task{cancel: cancel, resume: fn}
+	m.pausedTask = nil
+	m.taskMutex.Unlock()
+
+	m.InProgress(true)
+	defer m.InProgress(false)
+
+	m.resetProgress()
+	m.handleUIRefresh()
+
+	fn(ctx)
+	m.endTask()
+	return true
+}
+
+// setResume makes a resumed task continue with fn, tasks call it as they move to the next step.
+func (m *Model) setResume(fn func(ctx context.Context)) {
+	m.taskMutex.Lock()
+	defer m.taskMutex.Unlock()
+
+	if m.task != nil {
+		m.task.resume = fn
+	}
+}
+
+func (m *Model) endTask() {
+	m.taskMutex.Lock()
+	if m.task != nil {
+		m.task.cancel(nil)
+		m.task = nil
+	}
+	m.taskMutex.Unlock()
+
+	m.handleUIRefresh()
+}
+
+// TaskRunning reports whether an install or update can be paused or canceled.
+func (m *Model) TaskRunning() bool {
+	m.taskMutex.Lock()
+	defer m.taskMutex.Unlock()
+	return m.task != nil
+}
+
+// Paused reports whether a paused task can be resumed.
+func (m *Model) Paused() bool {
+	m.taskMutex.Lock()
+	defer m.taskMutex.Unlock()
+	return m.pausedTask != nil
+}
+
+// PauseTask stops the running task and keeps its partial download for ResumeTask or the next run.
+func (m *Model) PauseTask() {
+	m.taskMutex.Lock()
+	defer m.taskMutex.Unlock()
+
+	if m.task != nil {
+		m.logger.Info().Str(log.Lifecycle, "pausing task").Msg(log.AppManager.String())
+		m.task.cancel(log.ErrTaskPaused)
+	}
+}
+
+// ResumeTask continues the paused task from the step it was stopped at.
+func (m *Model) ResumeTask() {
+	m.taskMutex.Lock()
+	paused := m.pausedTask
+	if m.task != nil {
+		paused = nil
+	} else {
+		m.pausedTask = nil
+	}
+	m.taskMutex.Unlock()
+
+	if paused != nil {
+		m.logger.Info().Str(log.Lifecycle, "resuming task").Msg(log.AppManager.String())
+		go m.runTask(paused.resume)
+	}
+}
+
+// CancelTask stops the running or paused task and removes its partial download.
+func (m *Model) CancelTask() {
+	m.taskMutex.Lock()
+	running, paused := m.task, m.pausedTask
+	m.pausedTask = nil
+	m.taskMutex.Unlock()
+
+	switch {
+	case running != nil:
+		m.logger.Info().Str(log.Lifecycle, "canceling task").Msg(log.AppManager.String())
+		running.cancel(log.ErrTaskCanceled)
+	case paused != nil:
+		m.logger.Info().Str(log.Lifecycle, "canceling paused task").Msg(log.AppManager.String())
+		paused.discard()
+		m.publishProgress(ProgressEvent{Phase: PhaseCanceled, Tag: m.Progress().Tag})
+		m.handleUIRefresh()
+	}
+}
+
+// discardDownload removes a partial download and its segment state.
+func (m *Model) discardDownload(zipPath string) {
+	if zipPath == "" {
+		return
+	}
+	for _, path := range []string{zipPath, zipPath + download.StateSuffix} {
+		if !m.fs.Exist(path) {
+			continue
+		}
+		if err := m.fs.Remove(path); err != nil {
+			mErr := T("model_play.fail_artifact")
+			m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
+		}
+	}
+}
+
+// taskStopped reports whether ctx was paused or canceled, and cleans up after it.
+// zipPath is empty for tasks that download nothing. A build being extracted is removed
+// by installBuild, the installed one is left as it was.
+func (m *Model) taskStopped(ctx context.Context, zipPath string) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+
+	cause := context.Cause(ctx)
+	switch {
+	case errors.Is(cause, log.ErrTaskPaused):
+		m.taskMutex.Lock()
+		if m.task != nil {
+			m.task.discard = func() { m.discardDownload(zipPath) }
+			m.pausedTask = m.task
+		}
+		m.taskMutex.Unlock()
+
+		m.publishProgress(ProgressEvent{Phase: PhasePaused, Tag: m.Progress().Tag})
+		m.logger.Info().Str(log.Lifecycle, "task paused").Msg(log.AppManager.String())
+	case errors.Is(cause, log.ErrTaskCanceled):
+		m.discardDownload(zipPath)
+		m.publishProgress(ProgressEvent{Phase: PhaseCanceled, Tag: m.Progress().Tag})
+		m.logger.Info().Str(log.Lifecycle, "task canceled").Msg(log.AppManager.String())
+	default:
+		m.logger.Info().Str(log.Lifecycle, "task stopped").Err(cause).Msg(log.AppManager.String())
+	}
+
+	return true
+}
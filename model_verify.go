@@ -0,0 +1,234 @@
+/*
+ * SPDX-License-Identifier: GPL-3.0-only
+ * SPDX-FileCopyrightText: 2025 Project 86 Community
+ *
+ * Project-86-Launcher: A Launcher developed for Project-86-Community-Game for managing game files.
+ * Copyright (C) 2025 Project 86 Community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package p86l
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"p86l/configs"
+	"p86l/internal/checksum"
+	"p86l/internal/github"
+	"p86l/internal/log"
+	"p86l/internal/minisign"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fetchAsset downloads a small release asset, like a checksum file or build manifest, into memory.
+// It goes through the client of the release source and stops with ctx.
+func (m *Model) fetchAsset(ctx context.Context, url string) ([]byte, error) {
+	return m.client.Download(ctx, url, 16<<20)
+}
+
+// expectedDigest looks for the sha256 of asset in its GitHub digest, a checksum asset,
+// then the release notes. It is empty when the release publishes none.
+func (m *Model) expectedDigest(ctx context.Context, release *github.RepositoryRelease, asset *github.ReleaseAsset) (string, error) {
+	if strings.HasPrefix(strings.ToLower(asset.Digest), "sha256:") {
+		return checksum.Normalize(asset.Digest), nil
+	}
+
+	for _, sumsAsset := range release.Assets {
+		which, ok := checksum.IsSumsFile(sumsAsset.Name)
+		if !ok || (which != "" && which != asset.Name) {
+			continue
+		}
+
+		data, err := m.fetchAsset(ctx, sumsAsset.BrowserDownloadURL)
+		if err != nil {
+			return "", err
+		}
+
+		sums := checksum.Parse(string(data))
+		if sum := sums[asset.Name]; sum != "" {
+			return sum, nil
+		}
+		if sum := sums[""]; sum != "" && which != "" {
+			return sum, nil
+		}
+	}
+
+	return checksum.Parse(release.Body)[asset.Name], nil
+}
+
+// quarantine moves a file into temp/quarantine so it can be inspected, and returns its new path.
+func (m *Model) quarantine(path string) (string, error) {
+	dir := filepath.Join(configs.FolderTemp, configs.FolderQuarantine)
+	if err := m.fs.Root().MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	dest := filepath.Join(dir, fmt.Sprintf("%d-%s", time.Now().Unix(), filepath.Base(path)))
+	if err := m.fs.Root().Rename(path, dest); err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
+
+// verifyGame checks the downloaded zip against the release checksums before it is extracted,
+// a mismatching zip is quarantined. Releases without checksums are let through.
+func (m *Model) verifyGame(ctx context.Context, release *github.RepositoryRelease, asset *github.ReleaseAsset, zipPath string) bool {
+	m.publishProgress(ProgressEvent{Phase: PhaseVerify, Tag: release.TagName})
+
+	expected, err := m.expectedDigest(ctx, release, asset)
+	if err != nil {
+		mErr := T("model_play.fail_verify")
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
+		return false
+	}
+	if expected == "" {
+		m.logger.Warn().Str(log.Lifecycle, "release has no checksum, skipping verification").Str("tag", release.TagName).Msg(log.FileManager.String())
+		return m.verifySignature(ctx, release, asset, zipPath)
+	}
+
+	zipFile, err := m.fs.Root().Open(zipPath)
+	if err != nil {
+		mErr := T("model_play.fail_verify")
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
+		return false
+	}
+	actual, err := checksum.SHA256(zipFile)
+	_ = zipFile.Close()
+	if err != nil {
+		mErr := T("model_play.fail_verify")
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Err(err).Msg(log.ErrorManager.String())
+		return false
+	}
+
+	if actual != expected {
+		quarantinePath, qErr := m.quarantine(zipPath)
+		mErr := T("model_play.fail_checksum")
+		m.failProgress(mErr, fmt.Errorf("%w: %s", log.ErrChecksumMismatch, quarantinePath))
+		m.logger.Warn().
+			Str(log.Lifecycle, strings.ToLower(mErr)).
+			Str("expected", expected).
+			Str("actual", actual).
+			Str("quarantine", quarantinePath).
+			Err(errors.Join(log.ErrChecksumMismatch, qErr)).
+			Msg(log.ErrorManager.String())
+		return false
+	}
+
+	m.logger.Info().Str(log.Lifecycle, "checksum verified").Str("sha256", actual).Msg(log.FileManager.String())
+	return m.verifySignature(ctx, release, asset, zipPath)
+}
+
+// signatureAsset returns the .minisig or .sig companion of asset, or nil.
+func signatureAsset(release *github.RepositoryRelease, asset *github.ReleaseAsset) *github.ReleaseAsset {
+	for _, ext := range []string{".minisig", ".sig"} {
+		for i := range release.Assets {
+			if release.Assets[i].Name == asset.Name+ext {
+				return &release.Assets[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifySignature checks the zip against configs.SigningPublicKey. Zips without a valid signature are
+// refused unless SetAllowUnsigned was used. Signing is not enforced while no key is embedded.
+func (m *Model) verifySignature(ctx context.Context, release *github.RepositoryRelease, asset *github.ReleaseAsset, zipPath string) bool {
+	if m.allowUnsigned {
+		m.logger.Warn().Str(log.Lifecycle, "signature verification overridden").Str("tag", release.TagName).Msg(log.FileManager.String())
+		return true
+	}
+	if configs.SigningPublicKey == "" {
+		m.logger.Warn().Str(log.Lifecycle, "no signing key embedded, skipping signature verification").Str("tag", release.TagName).Msg(log.FileManager.String())
+		return true
+	}
+
+	fail := func(err error) bool {
+		mErr := T("model_play.fail_signature")
+		m.failProgress(mErr, err)
+		m.logger.Warn().Str(log.Lifecycle, strings.ToLower(mErr)).Str("tag", release.TagName).Err(err).Msg(log.ErrorManager.String())
+		return false
+	}
+
+	zipFile, err := m.fs.Root().Open(zipPath)
+	if err != nil {
+		return fail(err)
+	}
+	sigName, err := m.checkSignature(ctx, release, asset, zipFile)
+	_ = zipFile.Close()
+
+	if err != nil {
+		if errors.Is(err, log.ErrSignatureInvalid) {
+			quarantinePath, qErr := m.quarantine(zipPath)
+			m.logger.Warn().Str("quarantine", quarantinePath).AnErr("quarantine_error", qErr).Msg(log.FileManager.String())
+		}
+		return fail(err)
+	}
+
+	m.logger.Info().Str(log.Lifecycle, "signature verified").Str("signature", sigName).Msg(log.FileManager.String())
+	return true
+}
+
+// verifyManifest checks a build manifest against configs.SigningPublicKey like verifySignature checks
+// zips, delta updates trust the sha256 it lists for every file.
+func (m *Model) verifyManifest(ctx context.Context, release *github.RepositoryRelease, manAsset *github.ReleaseAsset, data []byte) error {
+	if m.allowUnsigned {
+		m.logger.Warn().Str(log.Lifecycle, "manifest signature verification overridden").Str("tag", release.TagName).Msg(log.FileManager.String())
+		return nil
+	}
+	if configs.SigningPublicKey == "" {
+		return nil
+	}
+
+	sigName, err := m.checkSignature(ctx, release, manAsset, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	m.logger.Info().Str(log.Lifecycle, "manifest signature verified").Str("signature", sigName).Msg(log.FileManager.String())
+	return nil
+}
+
+// checkSignature fetches the signature of asset and checks r, its content, against
+// configs.SigningPublicKey. It returns the name of the signature asset.
+func (m *Model) checkSignature(ctx context.Context, release *github.RepositoryRelease, asset *github.ReleaseAsset, r io.Reader) (string, error) {
+	pub, err := minisign.ParsePublicKey(configs.SigningPublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	sigAsset := signatureAsset(release, asset)
+	if sigAsset == nil {
+		return "", log.ErrSignatureMissing
+	}
+	sig, err := m.fetchAsset(ctx, sigAsset.BrowserDownloadURL)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasSuffix(sigAsset.Name, ".minisig") {
+		return sigAsset.Name, pub.Verify(r, sig)
+	}
+	return sigAsset.Name, pub.VerifyRaw(r, sig)
+}
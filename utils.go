@@ -26,6 +26,7 @@ import (
 	"cmp"
 	"fmt"
 	"image"
+	"net/url"
 	"os"
 	"p86l/assets"
 	"p86l/configs"
@@ -43,10 +44,45 @@ import (
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
-var (
-	PathGameStable     = filepath.Join(configs.FolderBuilds, configs.FolderStable, configs.FileGame)
-	PathGamePreRelease = filepath.Join(configs.FolderBuilds, configs.FolderPreRelease, configs.FileGame)
-)
+// GameFile returns the name of the game executable in a build for goos.
+func GameFile(goos string) string {
+	if goos == "linux" {
+		return configs.FileGameLinux
+	}
+	return configs.FileGame
+}
+
+// PathBuildVersion returns the build folder of a specific version.
+func PathBuildVersion(tag string) string {
+	return filepath.Join(configs.FolderBuilds, configs.FolderVersions, tagName(tag))
+}
+
+// tagName makes a release tag safe to use in a file name.
+func tagName(tag string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(tag)
+}
+
+func PathGameVersion(tag, goos string) string {
+	return filepath.Join(PathBuildVersion(tag), GameFile(goos))
+}
+
+// PathBuildZip returns where the archive of a build folder is downloaded, the default
+// channels keep stable-build.zip and prerelease-build.zip.
+func PathBuildZip(gamePath string) string {
+	return filepath.Join(configs.FolderTemp, fmt.Sprintf(configs.FileVersionZip, filepath.Base(gamePath)))
+}
+
+// PathRepairZip returns where repair downloads the archive of tag for a build folder. It is
+// kept apart from PathBuildZip so a paused install of the same folder is not resumed from it.
+func PathRepairZip(gamePath, tag string) string {
+	return filepath.Join(configs.FolderTemp, fmt.Sprintf(configs.FileRepairZip, filepath.Base(gamePath), tagName(tag)))
+}
+
+// PathRunnerPrefix returns the default WINEPREFIX of a build folder.
+func PathRunnerPrefix(gamePath string) string {
+	name := strings.ReplaceAll(filepath.ToSlash(strings.TrimPrefix(gamePath, configs.FolderBuilds+string(filepath.Separator))), "/", "_")
+	return filepath.Join(configs.FolderPrefixes, name)
+}
 
 func GetIcons() ([]image.Image, error) {
 	images, err := ico.DecodeAll(bytes.NewReader(assets.P86lIco))
@@ -149,71 +185,57 @@ func FormattedCacheExpireText(cache CacheFile) string {
 	return "..."
 }
 
-func ReleasesChangelogText(cache CacheFile, usePreRelease bool) string {
-	if cache.Releases != nil {
-		if usePreRelease {
-			return fmt.Sprintf("%s\n\n%s", cache.Releases.PreRelease.Name, cache.Releases.PreRelease.Body)
-		}
-		return fmt.Sprintf("%s\n\n%s", cache.Releases.Stable.Name, cache.Releases.Stable.Body)
+// ReleasesChangelogText takes the latest release of a channel, see Model.LatestRelease.
+func ReleasesChangelogText(release *github.RepositoryRelease) string {
+	if release != nil {
+		return fmt.Sprintf("%s\n\n%s", release.Name, release.Body)
 	}
 
 	return "..."
 }
 
-func GameVersionText(cache CacheFile, usePreRelease bool) string {
-	if cache.Releases != nil {
-		if usePreRelease {
-			return cache.Releases.PreRelease.TagName
-		}
-		return cache.Releases.Stable.TagName
+func GameVersionText(release *github.RepositoryRelease) string {
+	if release != nil {
+		return release.TagName
 	}
 
 	return "..."
 }
 
-func ReleasesDownloadCountText(cache CacheFile, usePreRelease bool) string {
-	if cache.Releases != nil {
-		if usePreRelease {
-			gameAsset := GetAssets(cache.Releases.PreRelease.Assets)
+func (m *Model) ReleasesDownloadCountText(release *github.RepositoryRelease) string {
+	if release != nil {
+		if gameAsset, err := m.GameAsset(release); err == nil {
 			return fmt.Sprintf("%d", gameAsset.DownloadCount)
 		}
-		gameAsset := GetAssets(cache.Releases.Stable.Assets)
-		return fmt.Sprintf("%d", gameAsset.DownloadCount)
 	}
 
 	return "..."
 }
 
-func isGameFile(filename string) bool {
-	return strings.Contains(filename, "Project86-v") &&
-		strings.Contains(filename, ".zip") &&
-		!strings.Contains(filename, "dev") &&
-		!strings.Contains(filename, "linux") &&
-		!strings.Contains(filename, "macOS")
-}
+// FindRelease returns the cached release tagged tag, or nil.
+func FindRelease(cache CacheFile, tag string) *github.RepositoryRelease {
+	for i := range cache.AllReleases {
+		if cache.AllReleases[i].TagName == tag {
+			return &cache.AllReleases[i]
+		}
+	}
 
-func isPrereleaseGameFile(filename string) bool {
-	return strings.Contains(filename, "Project86-v") &&
-		strings.Contains(filename, ".zip") &&
-		!strings.Contains(filename, "linux") &&
-		!strings.Contains(filename, "macOS")
+	return nil
 }
 
-func GetAssets(assets []github.ReleaseAsset) *github.ReleaseAsset {
-	var gameAsset *github.ReleaseAsset
-
-	for _, asset := range assets {
-		switch {
-		case isGameFile(asset.Name):
-			gameAsset = &asset
-			continue
-		case isPrereleaseGameFile(asset.Name):
-			gameAsset = &asset
+// AssetURLs returns where asset can be downloaded from, the release url first,
+// then each mirror as <mirror>/<tag>/<asset name>.
+func AssetURLs(mirrors []string, tag string, asset *github.ReleaseAsset) []string {
+	urls := []string{asset.BrowserDownloadURL}
+	for _, mirror := range mirrors {
+		mirrorURL, err := url.JoinPath(mirror, tag, asset.Name)
+		if err != nil || mirrorURL == asset.BrowserDownloadURL {
 			continue
 		}
+		urls = append(urls, mirrorURL)
 	}
 
-	return gameAsset
+	return urls
 }
 
 // current, new